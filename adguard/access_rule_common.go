@@ -0,0 +1,83 @@
+package adguard
+
+import (
+	"fmt"
+	"sync"
+
+	adgmodels "github.com/gmichels/adguard-client-go/models"
+)
+
+// the DNS access list can only be written as a whole, so ensure read-modify-write operations are serialized
+var accessListMutex sync.Mutex
+
+// valid DNS access rule types
+var accessRuleTypes = []string{"allowed_client", "disallowed_client", "blocked_host"}
+
+// getAccessRuleEntries - Return a pointer to the DNS access list entries for a rule type
+func getAccessRuleEntries(accessList *adgmodels.AccessList, ruleType string) *[]string {
+	switch ruleType {
+	case "allowed_client":
+		return &accessList.AllowedClients
+	case "disallowed_client":
+		return &accessList.DisallowedClients
+	default:
+		return &accessList.BlockedHosts
+	}
+}
+
+// checkAccessRuleConflict - Return an error if a client would be both allowed and disallowed
+func checkAccessRuleConflict(accessList *adgmodels.AccessList, ruleType string, value string) error {
+	var conflictingType string
+	switch ruleType {
+	case "allowed_client":
+		conflictingType = "disallowed_client"
+	case "disallowed_client":
+		conflictingType = "allowed_client"
+	default:
+		// blocked hosts cannot conflict with anything
+		return nil
+	}
+
+	if containsAccessRule(*getAccessRuleEntries(accessList, conflictingType), conflictingType, value) {
+		return fmt.Errorf("`%s` is already present in the DNS access list as `%s`, a client cannot be both allowed and disallowed", value, conflictingType)
+	}
+
+	return nil
+}
+
+// normalizeAccessRuleValue - Return the canonical form of a DNS access list entry, so equivalent entries compare equal
+func normalizeAccessRuleValue(ruleType string, value string) string {
+	if ruleType == "blocked_host" {
+		return normalizeDomain(value)
+	}
+	return normalizeClientId(value)
+}
+
+// findAccessRule - Return the DNS access list entry equivalent to the value, as spelled in AdGuard Home
+func findAccessRule(entries []string, ruleType string, value string) (string, bool) {
+	normalizedValue := normalizeAccessRuleValue(ruleType, value)
+	for _, entry := range entries {
+		if normalizeAccessRuleValue(ruleType, entry) == normalizedValue {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+// containsAccessRule - Return whether the DNS access list entries contain one equivalent to the value
+func containsAccessRule(entries []string, ruleType string, value string) bool {
+	_, found := findAccessRule(entries, ruleType, value)
+	return found
+}
+
+// removeAccessRule - Return the DNS access list entries without the ones equivalent to the value
+func removeAccessRule(entries []string, ruleType string, value string) []string {
+	normalizedValue := normalizeAccessRuleValue(ruleType, value)
+	remaining := []string{}
+	for _, entry := range entries {
+		if normalizeAccessRuleValue(ruleType, entry) != normalizedValue {
+			remaining = append(remaining, entry)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,374 @@
+package adguard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gmichels/adguard-client-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &accessRuleResource{}
+	_ resource.ResourceWithConfigure   = &accessRuleResource{}
+	_ resource.ResourceWithImportState = &accessRuleResource{}
+	_ resource.ResourceWithModifyPlan  = &accessRuleResource{}
+)
+
+// accessRuleResource is the resource implementation
+type accessRuleResource struct {
+	adg *adguard.ADG
+}
+
+// accessRuleResourceModel maps DNS access rule schema data
+type accessRuleResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	LastUpdated   types.String `tfsdk:"last_updated"`
+	Type          types.String `tfsdk:"type"`
+	Value         types.String `tfsdk:"value"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+}
+
+// NewAccessRuleResource is a helper function to simplify the provider implementation
+func NewAccessRuleResource() resource.Resource {
+	return &accessRuleResource{}
+}
+
+// Metadata returns the resource type name
+func (r *accessRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_rule"
+}
+
+// Schema defines the schema for the resource
+func (r *accessRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal identifier for this DNS access rule",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the DNS access rule",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of DNS access rule. Valid values are `allowed_client`, `disallowed_client` or `blocked_host`",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(accessRuleTypes...),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "IP address, CIDR or ClientID for client rules, or domain for blocked host rules",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					useStateForEquivalentAccessRule(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\S+$`),
+						"must not be empty or contain whitespace",
+					),
+					checkAccessRuleValue(),
+				},
+			},
+			"adopt_existing": adoptExistingResourceSchema("type and value"),
+		},
+	}
+}
+
+// ModifyPlan allows for validating plan values with dynamic options
+func (r *accessRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// if plan is null, then there is no plan to work with
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// retrieve plan
+	var plan accessRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// nothing to check when values are not yet known or there is no client yet
+	if plan.Type.IsUnknown() || plan.Value.IsUnknown() || r.adg == nil {
+		return
+	}
+
+	// retrieve state, which will be null on create operations
+	var state accessRuleResourceModel
+	if !req.State.Raw.IsNull() {
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// retrieve the current DNS access list
+	accessList, err := r.adg.AccessList()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading AdGuard Home DNS Access List",
+			"Could not read AdGuard Home DNS access list: "+err.Error(),
+		)
+		return
+	}
+
+	// the entry in state is removed before the new one is created, so it does not count as a conflict
+	if !req.State.Raw.IsNull() {
+		entries := getAccessRuleEntries(accessList, state.Type.ValueString())
+		*entries = removeAccessRule(*entries, state.Type.ValueString(), state.Value.ValueString())
+	}
+
+	// ensure the entry does not conflict with any other existing entry
+	err = checkAccessRuleConflict(accessList, plan.Type.ValueString(), plan.Value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("value"),
+			"DNS Access Rule Conflict",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *accessRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.adg = req.ProviderData.(*adguard.ADG)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *accessRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan accessRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ensure no other access rule changes the DNS access list in the meantime
+	accessListMutex.Lock()
+	defer accessListMutex.Unlock()
+
+	// retrieve the current DNS access list
+	accessList, err := r.adg.AccessList()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating DNS Access Rule",
+			"Could not create DNS access rule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// ensure the entry does not conflict with any other existing entry
+	err = checkAccessRuleConflict(accessList, plan.Type.ValueString(), plan.Value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating DNS Access Rule",
+			"Could not create DNS access rule: "+err.Error(),
+		)
+		return
+	}
+
+	// an existing entry is only taken over when adopting, as it would otherwise be removed on destroy
+	entries := getAccessRuleEntries(accessList, plan.Type.ValueString())
+	exists := containsAccessRule(*entries, plan.Type.ValueString(), plan.Value.ValueString())
+	if exists && !plan.AdoptExisting.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Error Creating DNS Access Rule",
+			fmt.Sprintf("Could not create DNS access rule: `%s` is already present in the DNS access list as `%s`. "+
+				"Set `adopt_existing` to `true` to manage the existing entry, or import it", plan.Value.ValueString(), plan.Type.ValueString()),
+		)
+		return
+	}
+
+	// add the entry, unless it is being adopted
+	if !exists {
+		*entries = append(*entries, plan.Value.ValueString())
+
+		// write back the updated DNS access list
+		err = r.adg.AccessSet(*accessList)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Creating DNS Access Rule",
+				"Could not create DNS access rule, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// add missing attributes for state
+	plan.ID = types.StringValue(plan.Type.ValueString() + "||" + plan.Value.ValueString())
+	// add the last updated attribute
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *accessRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get current state
+	var state accessRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// split the ID to separate type and value
+	idSplit := strings.SplitN(state.ID.ValueString(), "||", 2)
+	if len(idSplit) != 2 || !contains(accessRuleTypes, idSplit[0]) {
+		resp.Diagnostics.AddError(
+			"Invalid AdGuard Home DNS Access Rule ID",
+			fmt.Sprintf("Expected ID in the format `<type>||<value>`, where type is one of %s, got: %s", strings.Join(accessRuleTypes, ", "), state.ID.ValueString()),
+		)
+		return
+	}
+
+	// get refreshed DNS access list from AdGuard Home
+	accessList, err := r.adg.AccessList()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading AdGuard Home DNS Access Rule",
+			"Could not read AdGuard Home DNS access rule with ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	// convert to JSON for response logging
+	accessListJson, err := json.Marshal(accessList)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse AdGuard Home DNS Access List",
+			err.Error(),
+		)
+		return
+	}
+	// log response body
+	tflog.Debug(ctx, "ADG API response", map[string]interface{}{
+		"object": "accessList",
+		"body":   string(accessListJson),
+	})
+	entry, found := findAccessRule(*getAccessRuleEntries(accessList, idSplit[0]), idSplit[0], idSplit[1])
+	if !found {
+		resp.Diagnostics.AddWarning(
+			"AdGuard Home DNS Access Rule was deleted outside of Terraform",
+			"No such DNS access rule with id "+state.ID.ValueString(),
+		)
+		// remove from state
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// overwrite DNS access rule with refreshed state
+	state.Type = types.StringValue(idSplit[0])
+	state.Value = types.StringValue(entry)
+	// the adopt existing flag is null after an import
+	if state.AdoptExisting.IsNull() {
+		state.AdoptExisting = types.BoolValue(ADOPT_EXISTING)
+	}
+
+	// set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *accessRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// all configurable attributes require replacement, so there is nothing to update other than the timestamp
+	var plan accessRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update resource state with updated items and timestamp
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// update state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *accessRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// retrieve values from state
+	var state accessRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ensure no other access rule changes the DNS access list in the meantime
+	accessListMutex.Lock()
+	defer accessListMutex.Unlock()
+
+	// retrieve the current DNS access list
+	accessList, err := r.adg.AccessList()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting AdGuard Home DNS Access Rule",
+			"Could not delete DNS access rule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// remove the entry while keeping all others
+	entries := getAccessRuleEntries(accessList, state.Type.ValueString())
+	*entries = removeAccessRule(*entries, state.Type.ValueString(), state.Value.ValueString())
+
+	// write back the updated DNS access list
+	err = r.adg.AccessSet(*accessList)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting AdGuard Home DNS Access Rule",
+			"Could not delete DNS access rule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *accessRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,207 @@
+package adguard
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAccessRuleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "adguard_access_rule" "test" {
+  type  = "blocked_host"
+  value = "example.net"
+}
+
+resource "adguard_access_rule" "test_client" {
+  type  = "allowed_client"
+  value = "192.168.100.0/24"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_access_rule.test", "type", "blocked_host"),
+					resource.TestCheckResourceAttr("adguard_access_rule.test", "value", "example.net"),
+					resource.TestCheckResourceAttr("adguard_access_rule.test", "id", "blocked_host||example.net"),
+					resource.TestCheckResourceAttr("adguard_access_rule.test_client", "id", "allowed_client||192.168.100.0/24"),
+					resource.TestCheckResourceAttr("adguard_access_rule.test", "adopt_existing", "false"),
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("adguard_access_rule.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "adguard_access_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The last_updated attribute does not exist in AdGuard Home,
+				// therefore there is no value for it during import
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Conflict testing
+			{
+				Config: providerConfig + `
+resource "adguard_access_rule" "test" {
+  type  = "blocked_host"
+  value = "example.net"
+}
+
+resource "adguard_access_rule" "test_client" {
+  type  = "allowed_client"
+  value = "192.168.100.0/24"
+}
+
+resource "adguard_access_rule" "test_conflict" {
+  type  = "disallowed_client"
+  value = "192.168.100.0/24"
+}
+`,
+				ExpectError: regexp.MustCompile("DNS Access Rule Conflict"),
+			},
+			// Existing entry testing
+			{
+				Config: providerConfig + `
+resource "adguard_access_rule" "test" {
+  type  = "blocked_host"
+  value = "example.net"
+}
+
+resource "adguard_access_rule" "test_client" {
+  type  = "allowed_client"
+  value = "192.168.100.0/24"
+}
+
+resource "adguard_access_rule" "test_existing" {
+  type  = "blocked_host"
+  value = "version.bind"
+}
+`,
+				ExpectError: regexp.MustCompile("already present in the DNS access list"),
+			},
+			// Adoption testing
+			{
+				Config: providerConfig + `
+resource "adguard_access_rule" "test" {
+  type  = "blocked_host"
+  value = "example.net"
+}
+
+resource "adguard_access_rule" "test_client" {
+  type  = "allowed_client"
+  value = "192.168.100.0/24"
+}
+
+resource "adguard_access_rule" "test_adopt" {
+  type           = "blocked_host"
+  value          = "example.net"
+  adopt_existing = true
+
+  depends_on = [adguard_access_rule.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_access_rule.test_adopt", "id", "blocked_host||example.net"),
+					resource.TestCheckResourceAttr("adguard_access_rule.test_adopt", "adopt_existing", "true"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "adguard_access_rule" "test" {
+  type  = "blocked_host"
+  value = "example.org"
+}
+
+resource "adguard_access_rule" "test_client" {
+  type  = "disallowed_client"
+  value = "192.168.100.0/24"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_access_rule.test", "value", "example.org"),
+					resource.TestCheckResourceAttr("adguard_access_rule.test", "id", "blocked_host||example.org"),
+					resource.TestCheckResourceAttr("adguard_access_rule.test_client", "type", "disallowed_client"),
+					resource.TestCheckResourceAttr("adguard_access_rule.test_client", "id", "disallowed_client||192.168.100.0/24"),
+				),
+			},
+			// equivalent CIDR spelling must not produce a diff
+			{
+				Config: providerConfig + `
+resource "adguard_access_rule" "test" {
+  type  = "blocked_host"
+  value = "example.org"
+}
+
+resource "adguard_access_rule" "test_client" {
+  type  = "disallowed_client"
+  value = "192.168.100.7/24"
+}
+`,
+				PlanOnly: true,
+			},
+			// equivalent domain spelling must not produce a diff
+			{
+				Config: providerConfig + `
+resource "adguard_access_rule" "test" {
+  type  = "blocked_host"
+  value = "Example.ORG"
+}
+
+resource "adguard_access_rule" "test_client" {
+  type  = "disallowed_client"
+  value = "192.168.100.0/24"
+}
+`,
+				PlanOnly: true,
+			},
+			// invalid blocked host testing
+			{
+				Config: providerConfig + `
+resource "adguard_access_rule" "test" {
+  type  = "blocked_host"
+  value = "example..org"
+}
+
+resource "adguard_access_rule" "test_client" {
+  type  = "disallowed_client"
+  value = "192.168.100.0/24"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Domain"),
+			},
+			// coexistence with a config resource not managing the access list
+			{
+				Config: providerConfig + `
+resource "adguard_config" "test" {
+  dns = {
+    upstream_dns       = ["https://1.1.1.1/dns-query"]
+    manage_access_list = false
+  }
+}
+
+resource "adguard_access_rule" "test" {
+  type  = "blocked_host"
+  value = "example.org"
+}
+
+resource "adguard_access_rule" "test_client" {
+  type  = "disallowed_client"
+  value = "192.168.100.0/24"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_config.test", "dns.manage_access_list", "false"),
+					resource.TestCheckNoResourceAttr("adguard_config.test", "dns.blocked_hosts"),
+					resource.TestCheckNoResourceAttr("adguard_config.test", "dns.disallowed_clients"),
+					resource.TestCheckResourceAttr("adguard_access_rule.test", "id", "blocked_host||example.org"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,318 @@
+package adguard
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gmichels/adguard-client-go"
+	adgmodels "github.com/gmichels/adguard-client-go/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &blockedServicesResource{}
+	_ resource.ResourceWithConfigure   = &blockedServicesResource{}
+	_ resource.ResourceWithImportState = &blockedServicesResource{}
+	_ resource.ResourceWithModifyPlan  = &blockedServicesResource{}
+)
+
+// blockedServicesResource is the resource implementation
+type blockedServicesResource struct {
+	adg *adguard.ADG
+}
+
+// blockedServicesResourceModel maps global blocked services schema data
+type blockedServicesResourceModel struct {
+	ID                           types.String `tfsdk:"id"`
+	LastUpdated                  types.String `tfsdk:"last_updated"`
+	BlockedServices              types.Set    `tfsdk:"blocked_services"`
+	BlockedServicesPauseSchedule types.Object `tfsdk:"blocked_services_pause_schedule"`
+}
+
+// NewBlockedServicesResource is a helper function to simplify the provider implementation
+func NewBlockedServicesResource() resource.Resource {
+	return &blockedServicesResource{}
+}
+
+// Metadata returns the resource type name
+func (r *blockedServicesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blocked_services"
+}
+
+// Schema defines the schema for the resource
+func (r *blockedServicesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal identifier for the global blocked services",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the global blocked services",
+				Computed:    true,
+			},
+			"blocked_services": schema.SetAttribute{
+				Description: "Set of services to be blocked globally",
+				ElementType: types.StringType,
+				Required:    true,
+				// validation for provided values happens at ModifyPlan
+			},
+			"blocked_services_pause_schedule": scheduleResourceSchema(),
+		},
+	}
+}
+
+// ModifyPlan allows for validating plan values with dynamic options
+func (r *blockedServicesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// if plan is null, then there is no plan to work with
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// retrieve plan
+	var plan blockedServicesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// validate the provided blocked services in the plan
+	validateBlockedServices(ctx, plan.BlockedServices, resp)
+}
+
+// Configure adds the provider configured client to the resource
+func (r *blockedServicesResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.adg = req.ProviderData.(*adguard.ADG)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *blockedServicesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan blockedServicesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// defer to common function to create or update the resource
+	r.CreateOrUpdate(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// there is only one global list of blocked services, so hardcode the ID as 1
+	plan.ID = types.StringValue("1")
+	// add the last updated attribute
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *blockedServicesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get current state
+	var state blockedServicesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get refreshed blocked services from AdGuard Home
+	blockedServicesPauseSchedule, err := r.adg.BlockedServicesGet()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading AdGuard Home Blocked Services",
+			"Could not read AdGuard Home blocked services: "+err.Error(),
+		)
+		return
+	}
+	// convert to JSON for response logging
+	blockedServicesPauseScheduleJson, err := json.Marshal(blockedServicesPauseSchedule)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse AdGuard Home Blocked Services",
+			err.Error(),
+		)
+		return
+	}
+	// log response body
+	tflog.Debug(ctx, "ADG API response", map[string]interface{}{
+		"object": "blockedServicesPauseSchedule",
+		"body":   string(blockedServicesPauseScheduleJson),
+	})
+
+	// use common function to map blocked services pause schedules for each day
+	stateBlockedServicesPauseScheduleConfig := mapAdgScheduleToBlockedServicesPauseSchedule(ctx, &blockedServicesPauseSchedule.Schedule, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// need special handling for timezone due to inconsistent API response for `Local`
+	if !state.LastUpdated.IsNull() {
+		// unpack current state
+		var currStateBlockedServicesPauseScheduleConfig scheduleModel
+		diags = state.BlockedServicesPauseSchedule.As(ctx, &currStateBlockedServicesPauseScheduleConfig, basetypes.ObjectAsOptions{})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// if timezone in state is null, it means it was never defined, so we should ignore the inconsistent response from ADG
+		if !currStateBlockedServicesPauseScheduleConfig.TimeZone.IsNull() {
+			// map timezone from response
+			stateBlockedServicesPauseScheduleConfig.TimeZone = types.StringValue(blockedServicesPauseSchedule.Schedule.TimeZone)
+		}
+	} else {
+		// it's an import, map timezone from response
+		stateBlockedServicesPauseScheduleConfig.TimeZone = types.StringValue(blockedServicesPauseSchedule.Schedule.TimeZone)
+	}
+
+	// overwrite blocked services with refreshed state, ensuring an empty set instead of a null one
+	blockedServices := make([]string, 0)
+	blockedServices = append(blockedServices, blockedServicesPauseSchedule.Ids...)
+	state.BlockedServices, diags = types.SetValueFrom(ctx, types.StringType, blockedServices)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.BlockedServicesPauseSchedule, diags = types.ObjectValueFrom(ctx, scheduleModel{}.attrTypes(), &stateBlockedServicesPauseScheduleConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *blockedServicesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan blockedServicesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// defer to common function to create or update the resource
+	r.CreateOrUpdate(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update resource state with updated items and timestamp
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// update state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *blockedServicesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// there is no "real" delete for the blocked services, so this means clearing them and their pause schedule
+
+	// populate blocked services and schedules with default values
+	var blockedServicesPauseScheduleConfig adgmodels.BlockedServicesSchedule
+	blockedServicesPauseScheduleConfig.Ids = make([]string, 0)
+	blockedServicesPauseScheduleConfig.Schedule.TimeZone = BLOCKED_SERVICES_PAUSE_SCHEDULE_TIMEZONE
+	blockedServicesPauseScheduleConfig.Schedule.Sunday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Sunday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Monday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Monday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Tuesday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Tuesday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Wednesday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Wednesday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Thursday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Thursday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Friday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Friday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Saturday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+	blockedServicesPauseScheduleConfig.Schedule.Saturday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+
+	// set blocked services to defaults
+	err := r.adg.BlockedServicesUpdate(blockedServicesPauseScheduleConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting AdGuard Home Blocked Services",
+			"Could not delete blocked services, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *blockedServicesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// CreateOrUpdate - common function to create or update the global blocked services
+func (r *blockedServicesResource) CreateOrUpdate(ctx context.Context, plan *blockedServicesResourceModel, diags *diag.Diagnostics) {
+	// instantiate empty object for storing plan data
+	blockedServices := make([]string, 0)
+	// populate blocked services from plan
+	d := plan.BlockedServices.ElementsAs(ctx, &blockedServices, false)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+	// unpack nested attributes from plan
+	var planBlockedServicesPauseScheduleConfig scheduleModel
+	d = plan.BlockedServicesPauseSchedule.As(ctx, &planBlockedServicesPauseScheduleConfig, basetypes.ObjectAsOptions{})
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+	// instantiate empty object for storing plan data
+	var blockedServicesPauseScheduleConfig adgmodels.BlockedServicesSchedule
+	// populate blocked services schedule from plan
+	blockedServicesPauseScheduleConfig.Ids = blockedServices
+	// defer to common function to populate schedule
+	blockedServicesPauseScheduleConfig.Schedule = mapBlockedServicesPauseScheduleToAdgSchedule(ctx, planBlockedServicesPauseScheduleConfig, diags)
+	if diags.HasError() {
+		return
+	}
+
+	// set blocked services and schedule using plan
+	err := r.adg.BlockedServicesUpdate(blockedServicesPauseScheduleConfig)
+	if err != nil {
+		diags.AddError(
+			"Unable to Update AdGuard Home Blocked Services",
+			err.Error(),
+		)
+		return
+	}
+}
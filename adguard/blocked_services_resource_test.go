@@ -0,0 +1,69 @@
+package adguard
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBlockedServicesResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "adguard_blocked_services" "test" {
+	blocked_services = ["youtube", "pinterest"]
+	blocked_services_pause_schedule = {
+		time_zone = "America/Chicago"
+		sun = {
+			start = "18:15"
+			end = "23:15"
+		}
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_blocked_services.test", "blocked_services.#", "2"),
+					resource.TestCheckResourceAttr("adguard_blocked_services.test", "blocked_services.0", "pinterest"),
+					resource.TestCheckResourceAttr("adguard_blocked_services.test", "blocked_services_pause_schedule.time_zone", "America/Chicago"),
+					resource.TestCheckResourceAttr("adguard_blocked_services.test", "blocked_services_pause_schedule.sun.start", "18:15"),
+					resource.TestCheckResourceAttr("adguard_blocked_services.test", "blocked_services_pause_schedule.sun.end", "23:15"),
+					resource.TestCheckResourceAttr("adguard_blocked_services.test", "id", "1"),
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("adguard_blocked_services.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "adguard_blocked_services.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					// The last_updated attribute does not exist in AdGuard Home,
+					// therefore there is no value for it during import
+					"last_updated",
+					// time zone implementation by the AdGuard Home provides inconsistent results,
+					// which render verifying its import complicated
+					"blocked_services_pause_schedule.time_zone",
+				},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "adguard_blocked_services" "test" {
+	blocked_services = ["reddit"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_blocked_services.test", "blocked_services.#", "1"),
+					resource.TestCheckResourceAttr("adguard_blocked_services.test", "blocked_services.0", "reddit"),
+					resource.TestCheckNoResourceAttr("adguard_blocked_services.test", "blocked_services_pause_schedule.time_zone"),
+					resource.TestCheckNoResourceAttr("adguard_blocked_services.test", "blocked_services_pause_schedule.sun.start"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
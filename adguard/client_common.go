@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"reflect"
+	"strings"
 
 	"github.com/gmichels/adguard-client-go"
 	adgmodels "github.com/gmichels/adguard-client-go/models"
@@ -13,6 +14,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// tags accepted by AdGuard Home for persistent clients, which only allows this fixed set
+var clientTags = []string{
+	"device_audio", "device_camera", "device_gameconsole", "device_laptop", "device_nas", "device_other",
+	"device_pc", "device_phone", "device_printer", "device_securityalarm", "device_tablet", "device_tv",
+	"os_android", "os_ios", "os_linux", "os_macos", "os_other", "os_windows",
+	"user_admin", "user_child", "user_regular",
+}
+
 // common client model to be used for working with both resource and data source
 type clientCommonModel struct {
 	ID                           types.String `tfsdk:"id"`
@@ -40,14 +49,15 @@ func (o *clientCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	// initialize empty diags variable
 	var d diag.Diagnostics
 
-	// need to define client name based whether it's an import operation
-	var clientName string
+	// need to define client names to look up based whether it's an import operation
+	var clientNames []string
 	if !currState.Name.IsNull() {
 		// name exists in plan
-		clientName = currState.Name.ValueString()
-	} else {
-		// this is an import operation, use the ID
-		clientName = currState.ID.ValueString()
+		clientNames = append(clientNames, currState.Name.ValueString())
+	}
+	if !currState.ID.IsNull() && currState.ID.ValueString() != currState.Name.ValueString() {
+		// this is an import operation or the client was renamed, fall back to the ID
+		clientNames = append(clientNames, currState.ID.ValueString())
 	}
 
 	// retrieve all clients
@@ -64,27 +74,30 @@ func (o *clientCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	// assume client does not exist
 	clientExists := false
 
-	// check if this client exists
-	for _, currentClient := range allClients.Clients {
-		if currentClient.Name == clientName {
-			// found the client
-			clientExists = true
-			client = currentClient
-			// convert to JSON for response logging
-			clientJson, err := json.Marshal(client)
-			if err != nil {
-				diags.AddError(
-					"Unable to Parse AdGuard Home Client",
-					err.Error(),
-				)
-				return
+	// check if this client exists, looking up each name in order
+lookup:
+	for _, clientName := range clientNames {
+		for _, currentClient := range allClients.Clients {
+			if currentClient.Name == clientName {
+				// found the client
+				clientExists = true
+				client = currentClient
+				// convert to JSON for response logging
+				clientJson, err := json.Marshal(client)
+				if err != nil {
+					diags.AddError(
+						"Unable to Parse AdGuard Home Client",
+						err.Error(),
+					)
+					return
+				}
+				// log response body
+				tflog.Debug(ctx, "ADG API response", map[string]interface{}{
+					"object": "client",
+					"body":   string(clientJson),
+				})
+				break lookup
 			}
-			// log response body
-			tflog.Debug(ctx, "ADG API response", map[string]interface{}{
-				"object": "client",
-				"body":   string(clientJson),
-			})
-			break
 		}
 	}
 
@@ -92,14 +105,14 @@ func (o *clientCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 		// client not found
 		diags.AddError(
 			"Unable to Locate AdGuard Home Client",
-			"No client with name `"+clientName+"` exists in AdGuard Home.",
+			"No client with name `"+strings.Join(clientNames, "` or `")+"` exists in AdGuard Home.",
 		)
 		return
 	}
 
 	// map response body to model
 	o.Name = types.StringValue(client.Name)
-	o.Ids, d = types.SetValueFrom(ctx, types.StringType, client.Ids)
+	o.Ids, d = types.SetValueFrom(ctx, clientIdType, client.Ids)
 	diags.Append(d...)
 	if diags.HasError() {
 		return
@@ -49,7 +49,7 @@ func (d *clientDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 			},
 			"ids": schema.SetAttribute{
 				Description: "Set of identifiers for this client (IP, CIDR, MAC, or ClientID)",
-				ElementType: types.StringType,
+				ElementType: clientIdType,
 				Computed:    true,
 			},
 			"use_global_settings": schema.BoolAttribute{
@@ -12,3 +12,6 @@ const CLIENT_IGNORE_STATISTICS = false
 const CLIENT_UPSTREAMS_CACHE_ENABLED = false
 const CLIENT_UPSTREAMS_CACHE_SIZE = 0
 const CLIENT_UPSTREAMS_CACHE_SIZE_MAX = 4294967295
+
+// adguard_clients_policy defaults
+const CLIENTS_POLICY_MODE = "report"
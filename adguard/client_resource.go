@@ -3,7 +3,6 @@ package adguard
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"time"
 
 	"github.com/gmichels/adguard-client-go"
@@ -35,6 +34,12 @@ type clientResource struct {
 	adg *adguard.ADG
 }
 
+// clientResourceModel maps client schema data, adding the resource only attributes to the common model
+type clientResourceModel struct {
+	clientCommonModel
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
+}
+
 // NewClientResource is a helper function to simplify the provider implementation
 func NewClientResource() resource.Resource {
 	return &clientResource{}
@@ -60,24 +65,17 @@ func (r *clientResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Description: "Timestamp of the last Terraform update of the client",
 				Computed:    true,
 			},
+			"adopt_existing": adoptExistingResourceSchema("name"),
 			"name": schema.StringAttribute{
-				Description: "Name of the client",
+				Description: "Name of the client. Renaming the client is done in place",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"ids": schema.SetAttribute{
 				Description: "Set of identifiers for this client (IP, CIDR, MAC, or ClientID)",
-				ElementType: types.StringType,
+				ElementType: clientIdType,
 				Required:    true,
 				Validators: []validator.Set{
-					setvalidator.ValueStringsAre(
-						stringvalidator.RegexMatches(
-							regexp.MustCompile(`^[a-z0-9/.:-]+$`),
-							"must be an IP address/CIDR, MAC address, or only contain numbers, lowercase letters, and hyphens",
-						),
-					),
+					checkClientIds(),
 				},
 			},
 			"use_global_settings": schema.BoolAttribute{
@@ -125,13 +123,16 @@ func (r *clientResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Description: "List of upstream DNS server for this client",
 				ElementType: types.StringType,
 				Optional:    true,
-				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1), checkUpstreamDns()},
 			},
 			"tags": schema.SetAttribute{
-				Description: "Set of tags for this client",
+				Description: "Set of tags for this client. Valid values are the `device_*`, `os_*` and `user_*` tags supported by AdGuard Home",
 				ElementType: types.StringType,
 				Optional:    true,
-				Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(clientTags...)),
+				},
 			},
 			"ignore_querylog": schema.BoolAttribute{
 				Description: fmt.Sprintf("Whether to write to the query log. Defaults to `%t`", CLIENT_IGNORE_QUERYLOG),
@@ -172,7 +173,7 @@ func (r *clientResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 	}
 
 	// retrieve plan
-	var plan clientCommonModel
+	var plan clientResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -200,6 +201,27 @@ func (r *clientResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 	// update only the SafeSearch attribute in the plan
 	plan.SafeSearch = modifiedSafeSearch
 
+	// IDS
+	// ensure the IDs in the plan do not belong to another client
+	r.validateIdConflicts(ctx, req, plan, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// NAME
+	// the ID is the client name, so it will change when the client is renamed
+	if !req.State.Raw.IsNull() {
+		var state clientResourceModel
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !plan.Name.Equal(state.Name) {
+			plan.ID = types.StringUnknown()
+		}
+	}
+
 	// set the modified plan
 	diags = resp.Plan.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -208,6 +230,66 @@ func (r *clientResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 	}
 }
 
+// validateIdConflicts - checks the planned IDs against the IDs of all other persistent clients
+func (r *clientResource) validateIdConflicts(ctx context.Context, req resource.ModifyPlanRequest, plan clientResourceModel, resp *resource.ModifyPlanResponse) {
+	// the provider is not configured yet, or the IDs are only known after apply
+	if r.adg == nil || plan.Ids.IsUnknown() {
+		return
+	}
+
+	var planIds []networkAddressValue
+	diags := plan.Ids.ElementsAs(ctx, &planIds, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the client currently managed by this resource is excluded from the check,
+	// as is the client with the same name when it is adopted on create
+	managedName := ""
+	if req.State.Raw.IsNull() && plan.AdoptExisting.ValueBool() {
+		managedName = plan.Name.ValueString()
+	} else if !req.State.Raw.IsNull() {
+		var state clientResourceModel
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		managedName = state.ID.ValueString()
+	}
+
+	// retrieve all clients
+	allClients, err := r.adg.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read AdGuard Home Clients",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, planId := range planIds {
+		if planId.IsUnknown() {
+			continue
+		}
+		for _, client := range allClients.Clients {
+			if client.Name == managedName {
+				continue
+			}
+			for _, clientId := range client.Ids {
+				if clientIdsOverlap(planId.ValueString(), clientId) {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("ids").AtSetValue(planId),
+						"Client ID Conflict",
+						fmt.Sprintf("Client ID %q overlaps with the ID %q of the existing client %q", planId.ValueString(), clientId, client.Name),
+					)
+				}
+			}
+		}
+	}
+}
+
 // Configure adds the provider configured client to the resource
 func (r *clientResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -220,17 +302,37 @@ func (r *clientResource) Configure(_ context.Context, req resource.ConfigureRequ
 // Create creates the resource and sets the initial Terraform state
 func (r *clientResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// retrieve values from plan
-	var plan clientCommonModel
+	var plan clientResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// an existing client with the same name is updated instead of created when adopting
+	adopted := false
+	if plan.AdoptExisting.ValueBool() {
+		allClients, err := r.adg.Clients()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read AdGuard Home Clients",
+				err.Error(),
+			)
+			return
+		}
+		for _, client := range allClients.Clients {
+			if client.Name == plan.Name.ValueString() {
+				adopted = true
+				// the update is done using the current name
+				plan.ID = types.StringValue(client.Name)
+				break
+			}
+		}
+	}
+
 	// defer to common function to create or update the resource
-	r.CreateOrUpdate(ctx, &plan, &resp.Diagnostics, true)
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
+	r.CreateOrUpdate(ctx, &plan.clientCommonModel, &resp.Diagnostics, !adopted)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -251,7 +353,7 @@ func (r *clientResource) Create(ctx context.Context, req resource.CreateRequest,
 // Read refreshes the Terraform state with the latest data
 func (r *clientResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// get current state
-	var state clientCommonModel
+	var state clientResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -259,16 +361,21 @@ func (r *clientResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	// use common model for state
-	var newState clientCommonModel
+	var newState clientResourceModel
 	// use common Read function
-	newState.Read(ctx, *r.adg, &state, &resp.Diagnostics, "resource")
+	newState.Read(ctx, *r.adg, &state.clientCommonModel, &resp.Diagnostics, "resource")
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// populate internal fields into new state
-	newState.ID = state.ID
+	// populate internal fields into new state, the ID always being the current client name
+	newState.ID = newState.Name
 	newState.LastUpdated = state.LastUpdated
+	newState.AdoptExisting = state.AdoptExisting
+	// the adopt existing flag is null after an import
+	if newState.AdoptExisting.IsNull() {
+		newState.AdoptExisting = types.BoolValue(ADOPT_EXISTING)
+	}
 
 	// set refreshed state
 	diags = resp.State.Set(ctx, &newState)
@@ -281,20 +388,30 @@ func (r *clientResource) Read(ctx context.Context, req resource.ReadRequest, res
 // Update updates the resource and sets the updated Terraform state on success
 func (r *clientResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// retrieve values from plan
-	var plan clientCommonModel
+	var plan clientResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// retrieve state, as the client is updated using its current name
+	var state clientResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = state.ID
+
 	// defer to common function to create or update the resource
-	r.CreateOrUpdate(ctx, &plan, &resp.Diagnostics, false)
+	r.CreateOrUpdate(ctx, &plan.clientCommonModel, &resp.Diagnostics, false)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// update resource state with updated items and timestamp
+	// update resource state with the new name as ID, updated items and timestamp
+	plan.ID = types.StringValue(plan.Name.ValueString())
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	// update state
@@ -308,7 +425,7 @@ func (r *clientResource) Update(ctx context.Context, req resource.UpdateRequest,
 // Delete deletes the resource and removes the Terraform state on success
 func (r *clientResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// retrieve values from state
-	var state clientCommonModel
+	var state clientResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -1,15 +1,39 @@
 package adguard
 
 import (
+	"regexp"
 	"testing"
 
+	adgmodels "github.com/gmichels/adguard-client-go/models"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccClientResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
+			// Invalid upstream testing
+			{
+				Config: providerConfig + `
+resource "adguard_client" "test" {
+  name      = "Test Client"
+  ids       = ["192.168.100.15", "test-client"]
+  upstreams = ["ftp://1.1.1.1"]
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Upstream DNS Server"),
+			},
+			// Invalid ID testing
+			{
+				Config: providerConfig + `
+resource "adguard_client" "test" {
+  name = "Test Client"
+  ids  = ["192.168.100.15/33", "test-client"]
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Client ID"),
+			},
 			// Create and Read testing
 			{
 				Config: providerConfig + `
@@ -20,6 +44,7 @@ resource "adguard_client" "test" {
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("adguard_client.test", "name", "Test Client"),
+					resource.TestCheckResourceAttr("adguard_client.test", "adopt_existing", "false"),
 					resource.TestCheckResourceAttr("adguard_client.test", "ids.#", "2"),
 					resource.TestCheckResourceAttr("adguard_client.test", "ids.1", "test-client"),
 					// Verify dynamic values have any value set in the state.
@@ -87,7 +112,7 @@ resource "adguard_client" "test" {
 					resource.TestCheckResourceAttr("adguard_client.test", "upstreams_cache_size", "12345"),
 				),
 			},
-			// Update client name testing (requires recreate)
+			// Update client name testing (in place)
 			{
 				Config: providerConfig + `
 resource "adguard_client" "test" {
@@ -95,12 +120,100 @@ resource "adguard_client" "test" {
   ids  = ["192.168.100.15", "test-client", "another-test-client"]
 }
 `,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("adguard_client.test", plancheck.ResourceActionUpdate),
+					},
+				},
 				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_client.test", "id", "Test Client Name Updated"),
 					resource.TestCheckResourceAttr("adguard_client.test", "name", "Test Client Name Updated"),
 					resource.TestCheckResourceAttr("adguard_client.test", "ids.#", "3"),
 					resource.TestCheckResourceAttr("adguard_client.test", "ids.1", "another-test-client"),
 				),
 			},
+			// ImportState testing by the new name
+			{
+				ResourceName:      "adguard_client.test",
+				ImportState:       true,
+				ImportStateId:     "Test Client Name Updated",
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"last_updated",
+					"blocked_services_pause_schedule.time_zone",
+				},
+			},
+			// Adoption testing, with a client created outside of Terraform
+			{
+				PreConfig: func() {
+					adg, err := testAccAdguardClient()
+					if err == nil {
+						err = adg.ClientsAdd(adgmodels.Client{
+							Name:                     "Test Adopted Client",
+							Ids:                      []string{"192.168.100.16"},
+							UseGlobalSettings:        true,
+							UseGlobalBlockedServices: true,
+						})
+					}
+					if err != nil {
+						t.Fatalf("unable to create client outside of Terraform: %s", err)
+					}
+				},
+				Config: providerConfig + `
+resource "adguard_client" "test" {
+  name = "Test Client Name Updated"
+  ids  = ["192.168.100.15", "test-client", "another-test-client"]
+}
+
+resource "adguard_client" "test_adopt" {
+  name           = "Test Adopted Client"
+  ids            = ["192.168.100.16", "adopted-client"]
+  adopt_existing = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_client.test_adopt", "id", "Test Adopted Client"),
+					resource.TestCheckResourceAttr("adguard_client.test_adopt", "ids.#", "2"),
+					resource.TestCheckResourceAttr("adguard_client.test_adopt", "adopt_existing", "true"),
+				),
+			},
+			// Invalid domain-specific upstream testing
+			{
+				Config: providerConfig + `
+resource "adguard_client" "test" {
+  name      = "Test Client Name Updated"
+  ids       = ["192.168.100.15", "test-client", "another-test-client"]
+  upstreams = ["[/]1.1.1.1"]
+}
+
+resource "adguard_client" "test_adopt" {
+  name           = "Test Adopted Client"
+  ids            = ["192.168.100.16", "adopted-client"]
+  adopt_existing = true
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Upstream DNS Server"),
+			},
+			// Upstream for unqualified names testing
+			{
+				Config: providerConfig + `
+resource "adguard_client" "test" {
+  name      = "Test Client Name Updated"
+  ids       = ["192.168.100.15", "test-client", "another-test-client"]
+  upstreams = ["https://1.1.1.1/dns-query", "[//]192.168.100.1"]
+}
+
+resource "adguard_client" "test_adopt" {
+  name           = "Test Adopted Client"
+  ids            = ["192.168.100.16", "adopted-client"]
+  adopt_existing = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_client.test", "upstreams.#", "2"),
+					resource.TestCheckResourceAttr("adguard_client.test", "upstreams.1", "[//]192.168.100.1"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
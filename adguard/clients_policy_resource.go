@@ -0,0 +1,335 @@
+package adguard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gmichels/adguard-client-go"
+	adgmodels "github.com/gmichels/adguard-client-go/models"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &clientsPolicyResource{}
+	_ resource.ResourceWithConfigure   = &clientsPolicyResource{}
+	_ resource.ResourceWithImportState = &clientsPolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &clientsPolicyResource{}
+)
+
+// clientsPolicyResource is the resource implementation
+type clientsPolicyResource struct {
+	adg *adguard.ADG
+}
+
+// clientsPolicyResourceModel maps clients policy schema data
+type clientsPolicyResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	LastUpdated       types.String `tfsdk:"last_updated"`
+	ManagedTag        types.String `tfsdk:"managed_tag"`
+	ManagedNamePrefix types.String `tfsdk:"managed_name_prefix"`
+	Mode              types.String `tfsdk:"mode"`
+	UnmanagedClients  types.Set    `tfsdk:"unmanaged_clients"`
+}
+
+// NewClientsPolicyResource is a helper function to simplify the provider implementation
+func NewClientsPolicyResource() resource.Resource {
+	return &clientsPolicyResource{}
+}
+
+// Metadata returns the resource type name
+func (r *clientsPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clients_policy"
+}
+
+// Schema defines the schema for the resource
+func (r *clientsPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier attribute",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the clients policy",
+				Computed:    true,
+			},
+			"managed_tag": schema.StringAttribute{
+				Description: "Tag identifying the persistent clients managed by Terraform, one of the `device_*`, `os_*` and `user_*` tags supported by AdGuard Home. Exactly one of `managed_tag` or `managed_name_prefix` must be specified",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("managed_name_prefix")),
+					stringvalidator.OneOf(clientTags...),
+				},
+			},
+			"managed_name_prefix": schema.StringAttribute{
+				Description: "Name prefix identifying the persistent clients managed by Terraform. Exactly one of `managed_tag` or `managed_name_prefix` must be specified",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"mode": schema.StringAttribute{
+				Description: fmt.Sprintf("Action taken on persistent clients not managed by Terraform. Valid values are `report` (list them in `unmanaged_clients` and as a warning during plan) and `delete` (delete them). Defaults to `%s`", CLIENTS_POLICY_MODE),
+				Computed:    true,
+				Optional:    true,
+				Default:     stringdefault.StaticString(CLIENTS_POLICY_MODE),
+				Validators: []validator.String{
+					stringvalidator.OneOf("report", "delete"),
+				},
+			},
+			"unmanaged_clients": schema.SetAttribute{
+				Description: "Names of the persistent clients not managed by Terraform",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ModifyPlan lists the persistent clients not managed by Terraform, so they show up as drift
+func (r *clientsPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// if plan is null, then there is no plan to work with
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// retrieve plan
+	var plan clientsPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// nothing to check when values are not yet known or there is no client yet
+	if plan.ManagedTag.IsUnknown() || plan.ManagedNamePrefix.IsUnknown() || plan.Mode.IsUnknown() || r.adg == nil {
+		return
+	}
+
+	unmanagedClients := r.listUnmanagedClients(plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Mode.ValueString() == "delete" {
+		if len(unmanagedClients) > 0 {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("mode"),
+				"Unmanaged AdGuard Home Clients Will Be Deleted",
+				"The following persistent clients are not managed by Terraform and will be deleted: "+strings.Join(unmanagedClients, ", "),
+			)
+		}
+		// all unmanaged clients will be gone after apply
+		unmanagedClients = []string{}
+	} else if len(unmanagedClients) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("unmanaged_clients"),
+			"Unmanaged AdGuard Home Clients",
+			"The following persistent clients are not managed by Terraform: "+strings.Join(unmanagedClients, ", "),
+		)
+	}
+
+	plan.UnmanagedClients, diags = types.SetValueFrom(ctx, types.StringType, unmanagedClients)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// set the modified plan
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *clientsPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.adg = req.ProviderData.(*adguard.ADG)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *clientsPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan clientsPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// defer to common function to create or update the resource
+	r.CreateOrUpdate(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// there can be only one clients policy, so hardcode the ID as 1
+	plan.ID = types.StringValue("1")
+	// add the last updated attribute
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *clientsPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get current state
+	var state clientsPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the mode is null after an import
+	if state.Mode.IsNull() {
+		state.Mode = types.StringValue(CLIENTS_POLICY_MODE)
+	}
+
+	// nothing is managed after an import until a tag or name prefix is configured
+	unmanagedClients := []string{}
+	if !state.ManagedTag.IsNull() || !state.ManagedNamePrefix.IsNull() {
+		unmanagedClients = r.listUnmanagedClients(state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// overwrite unmanaged clients with refreshed state
+	state.UnmanagedClients, diags = types.SetValueFrom(ctx, types.StringType, unmanagedClients)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *clientsPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan clientsPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// defer to common function to create or update the resource
+	r.CreateOrUpdate(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update resource state with updated items and timestamp
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// update state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *clientsPolicyResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// the policy only exists in Terraform, so there is nothing to delete in AdGuard Home
+}
+
+func (r *clientsPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// common `Create` and `Update` function for the resource
+func (r *clientsPolicyResource) CreateOrUpdate(ctx context.Context, plan *clientsPolicyResourceModel, diags *diag.Diagnostics) {
+	// in report mode, the unmanaged clients listed during plan are kept as is
+	if plan.Mode.ValueString() != "delete" && !plan.UnmanagedClients.IsUnknown() {
+		return
+	}
+
+	unmanagedClients := r.listUnmanagedClients(*plan, diags)
+	if diags.HasError() {
+		return
+	}
+
+	if plan.Mode.ValueString() == "delete" {
+		for _, clientName := range unmanagedClients {
+			err := r.adg.ClientsDelete(adgmodels.ClientDelete{Name: clientName})
+			if err != nil {
+				diags.AddError(
+					"Error Deleting AdGuard Home Client",
+					"Could not delete unmanaged client "+clientName+", unexpected error: "+err.Error(),
+				)
+				return
+			}
+		}
+		unmanagedClients = []string{}
+	}
+
+	var d diag.Diagnostics
+	plan.UnmanagedClients, d = types.SetValueFrom(ctx, types.StringType, unmanagedClients)
+	diags.Append(d...)
+}
+
+// listUnmanagedClients - returns the sorted names of the persistent clients without the managed tag or name prefix
+func (r *clientsPolicyResource) listUnmanagedClients(policy clientsPolicyResourceModel, diags *diag.Diagnostics) []string {
+	allClients, err := r.adg.Clients()
+	if err != nil {
+		diags.AddError(
+			"Unable to Read AdGuard Home Clients",
+			err.Error(),
+		)
+		return nil
+	}
+
+	unmanagedClients := []string{}
+	for _, client := range allClients.Clients {
+		if !isManagedClient(client, policy.ManagedTag.ValueString(), policy.ManagedNamePrefix.ValueString()) {
+			unmanagedClients = append(unmanagedClients, client.Name)
+		}
+	}
+	sort.Strings(unmanagedClients)
+
+	return unmanagedClients
+}
+
+// isManagedClient - returns whether a client has the managed tag or its name starts with the managed prefix
+func isManagedClient(client adgmodels.Client, managedTag string, managedNamePrefix string) bool {
+	if managedTag != "" {
+		return contains(client.Tags, managedTag)
+	}
+	return strings.HasPrefix(client.Name, managedNamePrefix)
+}
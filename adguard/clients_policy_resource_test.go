@@ -0,0 +1,180 @@
+package adguard
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	adgmodels "github.com/gmichels/adguard-client-go/models"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccClientsPolicyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Both tag and name prefix testing
+			{
+				Config: providerConfig + `
+resource "adguard_clients_policy" "test" {
+  managed_tag         = "device_other"
+  managed_name_prefix = "tf-"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			// Tag not supported by AdGuard Home testing
+			{
+				Config: providerConfig + `
+resource "adguard_clients_policy" "test" {
+  managed_tag = "terraform"
+  mode        = "delete"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Match"),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "adguard_client" "managed" {
+  name = "tf-policy-managed"
+  ids  = ["192.168.100.40"]
+}
+
+resource "adguard_client" "unmanaged" {
+  name = "Policy Unmanaged"
+  ids  = ["192.168.100.41"]
+}
+
+resource "adguard_clients_policy" "test" {
+  managed_name_prefix = "tf-"
+
+  depends_on = [adguard_client.managed, adguard_client.unmanaged]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_clients_policy.test", "id", "1"),
+					resource.TestCheckResourceAttr("adguard_clients_policy.test", "mode", "report"),
+					resource.TestCheckTypeSetElemAttr("adguard_clients_policy.test", "unmanaged_clients.*", "Policy Unmanaged"),
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("adguard_clients_policy.test", "last_updated"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "adguard_client" "managed" {
+  name = "tf-policy-managed"
+  ids  = ["192.168.100.40"]
+}
+
+resource "adguard_client" "unmanaged" {
+  name = "Policy Unmanaged"
+  ids  = ["192.168.100.41"]
+}
+
+resource "adguard_clients_policy" "test" {
+  managed_name_prefix = "Policy "
+
+  depends_on = [adguard_client.managed, adguard_client.unmanaged]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_clients_policy.test", "managed_name_prefix", "Policy "),
+					resource.TestCheckTypeSetElemAttr("adguard_clients_policy.test", "unmanaged_clients.*", "tf-policy-managed"),
+				),
+			},
+			// Delete mode testing, with a client created outside of Terraform
+			{
+				PreConfig: func() {
+					adg, err := testAccAdguardClient()
+					if err == nil {
+						err = adg.ClientsAdd(adgmodels.Client{
+							Name:                     "Policy Out Of Band",
+							Ids:                      []string{"192.168.100.42"},
+							UseGlobalSettings:        true,
+							UseGlobalBlockedServices: true,
+						})
+					}
+					if err != nil {
+						t.Fatalf("unable to create client outside of Terraform: %s", err)
+					}
+				},
+				Config: providerConfig + `
+resource "adguard_client" "managed" {
+  name = "tf-policy-managed"
+  ids  = ["192.168.100.40"]
+  tags = ["device_other"]
+}
+
+resource "adguard_client" "unmanaged" {
+  name = "Policy Unmanaged"
+  ids  = ["192.168.100.41"]
+  tags = ["device_other"]
+}
+
+resource "adguard_clients_policy" "test" {
+  managed_tag = "device_other"
+  mode        = "delete"
+
+  depends_on = [adguard_client.managed, adguard_client.unmanaged]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_clients_policy.test", "mode", "delete"),
+					resource.TestCheckResourceAttr("adguard_clients_policy.test", "unmanaged_clients.#", "0"),
+					testAccCheckClientDeleted("Policy Out Of Band"),
+					// the tagged clients must be left alone
+					testAccCheckClientExists("tf-policy-managed"),
+					testAccCheckClientExists("Policy Unmanaged"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckClientDeleted - verifies a persistent client no longer exists in AdGuard Home
+func testAccCheckClientDeleted(name string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		adg, err := testAccAdguardClient()
+		if err != nil {
+			return err
+		}
+
+		allClients, err := adg.Clients()
+		if err != nil {
+			return err
+		}
+		for _, client := range allClients.Clients {
+			if client.Name == name {
+				return fmt.Errorf("client %s was not deleted", name)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckClientExists - verifies a persistent client still exists in AdGuard Home
+func testAccCheckClientExists(name string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		adg, err := testAccAdguardClient()
+		if err != nil {
+			return err
+		}
+
+		allClients, err := adg.Clients()
+		if err != nil {
+			return err
+		}
+		for _, client := range allClients.Clients {
+			if client.Name == name {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("client %s was deleted", name)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -294,6 +295,32 @@ func scheduleResourceSchema() schema.SingleNestedAttribute {
 	}
 }
 
+// private state key holding the server configuration captured before being managed by Terraform
+const snapshotPrivateStateKey = "snapshot"
+
+// provides on destroy schema for singleton resources
+func onDestroyResourceSchema() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: fmt.Sprintf("Behavior when the resource is destroyed. Valid values are `reset` (restore the AdGuard Home defaults), `retain` (leave the server configuration as is) and `restore_previous` (restore the server configuration captured when the resource was created). Defaults to `%s`", ON_DESTROY),
+		Computed:    true,
+		Optional:    true,
+		Default:     stringdefault.StaticString(ON_DESTROY),
+		Validators: []validator.String{
+			stringvalidator.OneOf("reset", "retain", "restore_previous"),
+		},
+	}
+}
+
+// provides adopt existing schema for resources which can take ownership of an existing object on create
+func adoptExistingResourceSchema(identifiedBy string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("When `true`, an existing object with the same %s is adopted and updated to match the configuration on create, instead of failing or creating a duplicate. Defaults to `%t`", identifiedBy, ADOPT_EXISTING),
+		Computed:    true,
+		Optional:    true,
+		Default:     booldefault.StaticBool(ADOPT_EXISTING),
+	}
+}
+
 // provides day range schema for datasources
 func dayRangeDatasourceSchema(day string) schema.SingleNestedAttribute {
 	return schema.SingleNestedAttribute{
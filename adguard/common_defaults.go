@@ -3,3 +3,5 @@ package adguard
 const SAFE_SEARCH_ENABLED = false
 const BLOCKED_SERVICES_PAUSE_SCHEDULE_TIMEZONE = "Local"
 const BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END = 0
+const ON_DESTROY = "reset"
+const ADOPT_EXISTING = false
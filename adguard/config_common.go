@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
-	"regexp"
 	"strconv"
 
 	"github.com/gmichels/adguard-client-go"
@@ -74,7 +73,7 @@ func (o queryLogConfigModel) attrTypes() map[string]attr.Type {
 		"enabled":             types.BoolType,
 		"interval":            types.Int64Type,
 		"anonymize_client_ip": types.BoolType,
-		"ignored":             types.SetType{ElemType: types.StringType},
+		"ignored":             types.SetType{ElemType: domainType{}},
 		"ignored_enabled":     types.BoolType,
 	}
 }
@@ -85,7 +84,7 @@ func (o queryLogConfigModel) defaultObject() map[string]attr.Value {
 		"enabled":             types.BoolValue(CONFIG_QUERYLOG_ENABLED),
 		"interval":            types.Int64Value(int64(CONFIG_QUERYLOG_INTERVAL)),
 		"anonymize_client_ip": types.BoolValue(CONFIG_QUERYLOG_ANONYMIZE_CLIENT_IP),
-		"ignored":             types.SetValueMust(types.StringType, []attr.Value{}),
+		"ignored":             types.SetValueMust(domainType{}, []attr.Value{}),
 		"ignored_enabled":     types.BoolValue(CONFIG_QUERYLOG_IGNORED_ENABLED),
 	}
 }
@@ -103,7 +102,7 @@ func (o statsConfigModel) attrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
 		"enabled":         types.BoolType,
 		"interval":        types.Int64Type,
-		"ignored":         types.SetType{ElemType: types.StringType},
+		"ignored":         types.SetType{ElemType: domainType{}},
 		"ignored_enabled": types.BoolType,
 	}
 }
@@ -113,7 +112,7 @@ func (o statsConfigModel) defaultObject() map[string]attr.Value {
 	return map[string]attr.Value{
 		"enabled":         types.BoolValue(CONFIG_STATS_ENABLED),
 		"interval":        types.Int64Value(CONFIG_STATS_INTERVAL),
-		"ignored":         types.SetValueMust(types.StringType, []attr.Value{}),
+		"ignored":         types.SetValueMust(domainType{}, []attr.Value{}),
 		"ignored_enabled": types.BoolValue(CONFIG_STATS_IGNORED_ENABLED),
 	}
 }
@@ -150,6 +149,7 @@ type dnsConfigModel struct {
 	AllowedClients         types.Set    `tfsdk:"allowed_clients"`
 	DisallowedClients      types.Set    `tfsdk:"disallowed_clients"`
 	BlockedHosts           types.Set    `tfsdk:"blocked_hosts"`
+	ManageAccessList       types.Bool   `tfsdk:"manage_access_list"`
 }
 
 // attrTypes - return attribute types for this model
@@ -182,9 +182,10 @@ func (o dnsConfigModel) attrTypes() map[string]attr.Type {
 		"resolve_clients":            types.BoolType,
 		"local_ptr_upstreams":        types.SetType{ElemType: types.StringType},
 		"upstream_timeout":           types.Int64Type,
-		"allowed_clients":            types.SetType{ElemType: types.StringType},
-		"disallowed_clients":         types.SetType{ElemType: types.StringType},
-		"blocked_hosts":              types.SetType{ElemType: types.StringType},
+		"allowed_clients":            types.SetType{ElemType: clientIdType},
+		"disallowed_clients":         types.SetType{ElemType: clientIdType},
+		"blocked_hosts":              types.SetType{ElemType: domainType{}},
+		"manage_access_list":         types.BoolType,
 	}
 }
 
@@ -192,7 +193,7 @@ func (o dnsConfigModel) attrTypes() map[string]attr.Type {
 func (o dnsConfigModel) defaultObject() map[string]attr.Value {
 	bootstrap_dns := convertToAttr(CONFIG_DNS_BOOTSTRAP)
 	upstream_dns := convertToAttr(CONFIG_DNS_UPSTREAM)
-	blocked_hosts := convertToAttr(CONFIG_DNS_BLOCKED_HOSTS)
+	blocked_hosts := convertToDomainAttr(CONFIG_DNS_BLOCKED_HOSTS)
 
 	return map[string]attr.Value{
 		"bootstrap_dns":              types.ListValueMust(types.StringType, bootstrap_dns),
@@ -222,9 +223,10 @@ func (o dnsConfigModel) defaultObject() map[string]attr.Value {
 		"resolve_clients":            types.BoolValue(CONFIG_DNS_RESOLVE_CLIENTS),
 		"local_ptr_upstreams":        types.SetValueMust(types.StringType, []attr.Value{}),
 		"upstream_timeout":           types.Int64Value(CONFIG_DNS_UPSTREAM_TIMEOUT),
-		"allowed_clients":            types.SetNull(types.StringType),
-		"disallowed_clients":         types.SetNull(types.StringType),
-		"blocked_hosts":              types.SetValueMust(types.StringType, blocked_hosts),
+		"allowed_clients":            types.SetNull(clientIdType),
+		"disallowed_clients":         types.SetNull(clientIdType),
+		"blocked_hosts":              types.SetValueMust(domainType{}, blocked_hosts),
+		"manage_access_list":         types.BoolValue(CONFIG_DNS_MANAGE_ACCESS_LIST),
 	}
 }
 
@@ -252,32 +254,35 @@ func (o dhcpStatusModel) attrTypes() map[string]attr.Type {
 
 // dhcpConfigModel maps DHCP schema data
 type dhcpConfigModel struct {
-	Enabled      types.Bool   `tfsdk:"enabled"`
-	Interface    types.String `tfsdk:"interface"`
-	Ipv4Settings types.Object `tfsdk:"ipv4_settings"`
-	Ipv6Settings types.Object `tfsdk:"ipv6_settings"`
-	StaticLeases types.Set    `tfsdk:"static_leases"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	Interface          types.String `tfsdk:"interface"`
+	Ipv4Settings       types.Object `tfsdk:"ipv4_settings"`
+	Ipv6Settings       types.Object `tfsdk:"ipv6_settings"`
+	StaticLeases       types.Set    `tfsdk:"static_leases"`
+	ManageStaticLeases types.Bool   `tfsdk:"manage_static_leases"`
 }
 
 // attrTypes - return attribute types for this model
 func (o dhcpConfigModel) attrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"enabled":       types.BoolType,
-		"interface":     types.StringType,
-		"ipv4_settings": types.ObjectType{AttrTypes: dhcpIpv4Model{}.attrTypes()},
-		"ipv6_settings": types.ObjectType{AttrTypes: dhcpIpv6Model{}.attrTypes()},
-		"static_leases": types.SetType{ElemType: types.ObjectType{AttrTypes: dhcpStaticLeasesModel{}.attrTypes()}},
+		"enabled":              types.BoolType,
+		"interface":            types.StringType,
+		"ipv4_settings":        types.ObjectType{AttrTypes: dhcpIpv4Model{}.attrTypes()},
+		"ipv6_settings":        types.ObjectType{AttrTypes: dhcpIpv6Model{}.attrTypes()},
+		"static_leases":        types.SetType{ElemType: types.ObjectType{AttrTypes: dhcpStaticLeasesModel{}.attrTypes()}},
+		"manage_static_leases": types.BoolType,
 	}
 }
 
 // defaultObject - return default object for this model
 func (o dhcpConfigModel) defaultObject() map[string]attr.Value {
 	return map[string]attr.Value{
-		"enabled":       types.BoolValue(CONFIG_DHCP_ENABLED),
-		"interface":     types.StringValue(""),
-		"ipv4_settings": types.ObjectValueMust(dhcpIpv4Model{}.attrTypes(), dhcpIpv4Model{}.defaultObject()),
-		"ipv6_settings": types.ObjectValueMust(dhcpIpv6Model{}.attrTypes(), dhcpIpv6Model{}.defaultObject()),
-		"static_leases": types.SetNull(types.ObjectType{AttrTypes: dhcpStaticLeasesModel{}.attrTypes()}),
+		"enabled":              types.BoolValue(CONFIG_DHCP_ENABLED),
+		"interface":            types.StringValue(""),
+		"ipv4_settings":        types.ObjectValueMust(dhcpIpv4Model{}.attrTypes(), dhcpIpv4Model{}.defaultObject()),
+		"ipv6_settings":        types.ObjectValueMust(dhcpIpv6Model{}.attrTypes(), dhcpIpv6Model{}.defaultObject()),
+		"static_leases":        types.SetNull(types.ObjectType{AttrTypes: dhcpStaticLeasesModel{}.attrTypes()}),
+		"manage_static_leases": types.BoolValue(CONFIG_DHCP_MANAGE_STATIC_LEASES),
 	}
 }
 
@@ -314,14 +319,14 @@ func (o dhcpIpv4Model) defaultObject() map[string]attr.Value {
 
 // dhcpIpv6Model maps DHCP IPv6 settings schema data
 type dhcpIpv6Model struct {
-	RangeStart    types.String `tfsdk:"range_start"`
-	LeaseDuration types.Int64  `tfsdk:"lease_duration"`
+	RangeStart    networkAddressValue `tfsdk:"range_start"`
+	LeaseDuration types.Int64         `tfsdk:"lease_duration"`
 }
 
 // attrTypes - return attribute types for this model
 func (o dhcpIpv6Model) attrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"range_start":    types.StringType,
+		"range_start":    ipAddressType,
 		"lease_duration": types.Int64Type,
 	}
 }
@@ -329,7 +334,7 @@ func (o dhcpIpv6Model) attrTypes() map[string]attr.Type {
 // defaultObject - return default object for this model
 func (o dhcpIpv6Model) defaultObject() map[string]attr.Value {
 	return map[string]attr.Value{
-		"range_start":    types.StringValue(""),
+		"range_start":    newNetworkAddressValue(ipAddressType, ""),
 		"lease_duration": types.Int64Value(CONFIG_DHCP_V6_LEASE_DURATION),
 	}
 }
@@ -354,105 +359,326 @@ func (o dhcpLeasesModel) attrTypes() map[string]attr.Type {
 
 // dhcpStaticLeasesModel maps DHCP leases schema data
 type dhcpStaticLeasesModel struct {
-	Mac      types.String `tfsdk:"mac"`
-	Ip       types.String `tfsdk:"ip"`
-	Hostname types.String `tfsdk:"hostname"`
+	Mac      networkAddressValue `tfsdk:"mac"`
+	Ip       networkAddressValue `tfsdk:"ip"`
+	Hostname types.String        `tfsdk:"hostname"`
 }
 
 // attrTypes - return attribute types for this model
 func (o dhcpStaticLeasesModel) attrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"mac":      types.StringType,
-		"ip":       types.StringType,
+		"mac":      macAddressType,
+		"ip":       ipAddressType,
 		"hostname": types.StringType,
 	}
 }
 
 // tlsConfigModel maps filtering schema data
 type tlsConfigModel struct {
-	Enabled           types.Bool   `tfsdk:"enabled"`
-	ServerName        types.String `tfsdk:"server_name"`
-	ForceHttps        types.Bool   `tfsdk:"force_https"`
-	PortHttps         types.Int64  `tfsdk:"port_https"`
-	PortDnsOverTls    types.Int64  `tfsdk:"port_dns_over_tls"`
-	PortDnsOverQuic   types.Int64  `tfsdk:"port_dns_over_quic"`
-	CertificateChain  types.String `tfsdk:"certificate_chain"`
-	PrivateKey        types.String `tfsdk:"private_key"`
-	PrivateKeySaved   types.Bool   `tfsdk:"private_key_saved"`
-	ValidCert         types.Bool   `tfsdk:"valid_cert"`
-	ValidChain        types.Bool   `tfsdk:"valid_chain"`
-	Subject           types.String `tfsdk:"subject"`
-	Issuer            types.String `tfsdk:"issuer"`
-	NotBefore         types.String `tfsdk:"not_before"`
-	NotAfter          types.String `tfsdk:"not_after"`
-	DnsNames          types.List   `tfsdk:"dns_names"`
-	ValidKey          types.Bool   `tfsdk:"valid_key"`
-	KeyType           types.String `tfsdk:"key_type"`
-	WarningValidation types.String `tfsdk:"warning_validation"`
-	ValidPair         types.Bool   `tfsdk:"valid_pair"`
-	ServePlainDns     types.Bool   `tfsdk:"serve_plain_dns"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	ServerName         types.String `tfsdk:"server_name"`
+	ForceHttps         types.Bool   `tfsdk:"force_https"`
+	PortHttps          types.Int64  `tfsdk:"port_https"`
+	PortDnsOverTls     types.Int64  `tfsdk:"port_dns_over_tls"`
+	PortDnsOverQuic    types.Int64  `tfsdk:"port_dns_over_quic"`
+	CertificateChain   types.String `tfsdk:"certificate_chain"`
+	PrivateKey         types.String `tfsdk:"private_key"`
+	PrivateKeySaved    types.Bool   `tfsdk:"private_key_saved"`
+	ValidCert          types.Bool   `tfsdk:"valid_cert"`
+	ValidChain         types.Bool   `tfsdk:"valid_chain"`
+	Subject            types.String `tfsdk:"subject"`
+	Issuer             types.String `tfsdk:"issuer"`
+	NotBefore          types.String `tfsdk:"not_before"`
+	NotAfter           types.String `tfsdk:"not_after"`
+	DnsNames           types.List   `tfsdk:"dns_names"`
+	ValidKey           types.Bool   `tfsdk:"valid_key"`
+	KeyType            types.String `tfsdk:"key_type"`
+	WarningValidation  types.String `tfsdk:"warning_validation"`
+	ValidPair          types.Bool   `tfsdk:"valid_pair"`
+	ServePlainDns      types.Bool   `tfsdk:"serve_plain_dns"`
+	ExpiryWarningDays  types.Int64  `tfsdk:"expiry_warning_days"`
+	ValidateWithServer types.Bool   `tfsdk:"validate_with_server"`
 }
 
 // attrTypes - return attribute types for this model
 func (o tlsConfigModel) attrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"enabled":            types.BoolType,
-		"server_name":        types.StringType,
-		"force_https":        types.BoolType,
-		"port_https":         types.Int64Type,
-		"port_dns_over_tls":  types.Int64Type,
-		"port_dns_over_quic": types.Int64Type,
-		"certificate_chain":  types.StringType,
-		"private_key":        types.StringType,
-		"private_key_saved":  types.BoolType,
-		"valid_cert":         types.BoolType,
-		"valid_chain":        types.BoolType,
-		"subject":            types.StringType,
-		"issuer":             types.StringType,
-		"not_before":         types.StringType,
-		"not_after":          types.StringType,
-		"dns_names":          types.ListType{ElemType: types.StringType},
-		"valid_key":          types.BoolType,
-		"key_type":           types.StringType,
-		"warning_validation": types.StringType,
-		"valid_pair":         types.BoolType,
-		"serve_plain_dns":    types.BoolType,
+		"enabled":              types.BoolType,
+		"server_name":          types.StringType,
+		"force_https":          types.BoolType,
+		"port_https":           types.Int64Type,
+		"port_dns_over_tls":    types.Int64Type,
+		"port_dns_over_quic":   types.Int64Type,
+		"certificate_chain":    types.StringType,
+		"private_key":          types.StringType,
+		"private_key_saved":    types.BoolType,
+		"valid_cert":           types.BoolType,
+		"valid_chain":          types.BoolType,
+		"subject":              types.StringType,
+		"issuer":               types.StringType,
+		"not_before":           types.StringType,
+		"not_after":            types.StringType,
+		"dns_names":            types.ListType{ElemType: types.StringType},
+		"valid_key":            types.BoolType,
+		"key_type":             types.StringType,
+		"warning_validation":   types.StringType,
+		"valid_pair":           types.BoolType,
+		"serve_plain_dns":      types.BoolType,
+		"expiry_warning_days":  types.Int64Type,
+		"validate_with_server": types.BoolType,
 	}
 }
 
 // defaultObject - return default object for this model
 func (o tlsConfigModel) defaultObject() map[string]attr.Value {
 	return map[string]attr.Value{
-		"enabled":            types.BoolValue(CONFIG_TLS_ENABLED),
-		"server_name":        types.StringValue(""),
-		"force_https":        types.BoolValue(CONFIG_TLS_FORCE_HTTPS),
-		"port_https":         types.Int64Value(CONFIG_TLS_PORT_HTTPS),
-		"port_dns_over_tls":  types.Int64Value(CONFIG_TLS_PORT_DNS_OVER_TLS),
-		"port_dns_over_quic": types.Int64Value(CONFIG_TLS_PORT_DNS_OVER_QUIC),
-		"certificate_chain":  types.StringValue(""),
-		"private_key":        types.StringValue(""),
-		"private_key_saved":  types.BoolValue(false),
-		"valid_cert":         types.BoolValue(false),
-		"valid_chain":        types.BoolValue(false),
-		"valid_key":          types.BoolValue(false),
-		"valid_pair":         types.BoolValue(false),
-		"key_type":           types.StringValue(""),
-		"subject":            types.StringValue(""),
-		"issuer":             types.StringValue(""),
-		"not_before":         types.StringValue(""),
-		"not_after":          types.StringValue(""),
-		"dns_names":          types.ListValueMust(types.StringType, []attr.Value{}),
-		"warning_validation": types.StringValue(""),
-		"serve_plain_dns":    types.BoolValue(CONFIG_TLS_SERVE_PLAIN_DNS),
+		"enabled":              types.BoolValue(CONFIG_TLS_ENABLED),
+		"server_name":          types.StringValue(""),
+		"force_https":          types.BoolValue(CONFIG_TLS_FORCE_HTTPS),
+		"port_https":           types.Int64Value(CONFIG_TLS_PORT_HTTPS),
+		"port_dns_over_tls":    types.Int64Value(CONFIG_TLS_PORT_DNS_OVER_TLS),
+		"port_dns_over_quic":   types.Int64Value(CONFIG_TLS_PORT_DNS_OVER_QUIC),
+		"certificate_chain":    types.StringValue(""),
+		"private_key":          types.StringValue(""),
+		"private_key_saved":    types.BoolValue(false),
+		"valid_cert":           types.BoolValue(false),
+		"valid_chain":          types.BoolValue(false),
+		"valid_key":            types.BoolValue(false),
+		"valid_pair":           types.BoolValue(false),
+		"key_type":             types.StringValue(""),
+		"subject":              types.StringValue(""),
+		"issuer":               types.StringValue(""),
+		"not_before":           types.StringValue(""),
+		"not_after":            types.StringValue(""),
+		"dns_names":            types.ListValueMust(types.StringType, []attr.Value{}),
+		"warning_validation":   types.StringValue(""),
+		"serve_plain_dns":      types.BoolValue(CONFIG_TLS_SERVE_PLAIN_DNS),
+		"expiry_warning_days":  types.Int64Value(CONFIG_TLS_EXPIRY_WARNING_DAYS),
+		"validate_with_server": types.BoolValue(CONFIG_TLS_VALIDATE_WITH_SERVER),
 	}
 }
 
 // common `Read` function for both data source and resource
-func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string) {
+func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string, partialOwnership bool) {
+	// with partial ownership, sections absent from the current state are left alone
+	isOwned := func(section attr.Value) bool {
+		return rtype != "resource" || !partialOwnership || !section.IsNull()
+	}
+
+	// FILTERING CONFIG
+	if isOwned(currState.Filtering) {
+		o.readFiltering(ctx, adg, currState, diags, rtype)
+	} else {
+		o.Filtering = types.ObjectNull(filteringModel{}.attrTypes())
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// SAFE BROWSING
+	if isOwned(currState.SafeBrowsing) {
+		o.readSafeBrowsing(ctx, adg, currState, diags, rtype)
+	} else {
+		o.SafeBrowsing = types.BoolNull()
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// PARENTAL CONTROL
+	if isOwned(currState.ParentalControl) {
+		o.readParentalControl(ctx, adg, currState, diags, rtype)
+	} else {
+		o.ParentalControl = types.BoolNull()
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// SAFE SEARCH
+	if isOwned(currState.SafeSearch) {
+		o.readSafeSearch(ctx, adg, currState, diags, rtype)
+	} else {
+		o.SafeSearch = types.ObjectNull(safeSearchModel{}.attrTypes())
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// QUERY LOG
+	if isOwned(currState.QueryLog) {
+		o.readQueryLog(ctx, adg, currState, diags, rtype)
+	} else {
+		o.QueryLog = types.ObjectNull(queryLogConfigModel{}.attrTypes())
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// STATS
+	if isOwned(currState.Stats) {
+		o.readStats(ctx, adg, currState, diags, rtype)
+	} else {
+		o.Stats = types.ObjectNull(statsConfigModel{}.attrTypes())
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// BLOCKED SERVICES
+	if isOwned(currState.BlockedServicesPauseSchedule) {
+		o.readBlockedServices(ctx, adg, currState, diags, rtype)
+	} else {
+		o.BlockedServices = types.SetNull(types.StringType)
+		o.BlockedServicesPauseSchedule = types.ObjectNull(scheduleModel{}.attrTypes())
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// DNS CONFIG
+	if isOwned(currState.Dns) {
+		o.readDns(ctx, adg, currState, diags, rtype)
+	} else {
+		o.Dns = types.ObjectNull(dnsConfigModel{}.attrTypes())
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// DHCP
+	if isOwned(currState.Dhcp) {
+		o.readDhcp(ctx, adg, currState, diags, rtype)
+	} else {
+		o.Dhcp = types.ObjectNull(dhcpConfigModel{}.attrTypes())
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// TLS
+	if isOwned(currState.Tls) {
+		o.readTls(ctx, adg, currState, diags, rtype)
+	} else {
+		o.Tls = types.ObjectNull(tlsConfigModel{}.attrTypes())
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// REWRITES
+	if isOwned(currState.Rewrites) {
+		o.readRewrites(ctx, adg, currState, diags, rtype)
+	} else {
+		o.Rewrites = types.BoolNull()
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// if we got here, all went fine
+}
+
+// common `Create` and `Update` function for the resource
+func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
+	// sections can only be null in the plan when not owned with partial ownership, in which case they are skipped
+
+	// FILTERING CONFIG
+	if !plan.Filtering.IsNull() {
+		r.updateFiltering(ctx, plan, state, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// SAFE BROWSING
+	if !plan.SafeBrowsing.IsNull() {
+		r.updateSafeBrowsing(ctx, plan, state, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// PARENTAL CONTROL
+	if !plan.ParentalControl.IsNull() {
+		r.updateParentalControl(ctx, plan, state, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// SAFE SEARCH
+	if !plan.SafeSearch.IsNull() {
+		r.updateSafeSearch(ctx, plan, state, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// QUERY LOG
+	if !plan.QueryLog.IsNull() {
+		r.updateQueryLog(ctx, plan, state, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// STATS
+	if !plan.Stats.IsNull() {
+		r.updateStats(ctx, plan, state, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// BLOCKED SERVICES
+	if !plan.BlockedServicesPauseSchedule.IsNull() {
+		r.updateBlockedServices(ctx, plan, state, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// DNS CONFIG
+	if !plan.Dns.IsNull() {
+		r.updateDns(ctx, plan, state, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// DHCP
+	if !plan.Dhcp.IsNull() {
+		r.updateDhcp(ctx, plan, state, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// TLS CONFIG
+	if !plan.Tls.IsNull() {
+		r.updateTls(ctx, plan, state, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// REWRITES
+	if !plan.Rewrites.IsNull() {
+		r.updateRewrites(ctx, plan, state, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// if we got here, all went fine
+}
+
+// readFiltering - reads the filtering config from AdGuard Home into the config model
+func (o *configCommonModel) readFiltering(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string) {
 	// initialize empty diags variable
 	var d diag.Diagnostics
 
-	// FILTERING CONFIG
 	// get refreshed filtering config value from AdGuard Home
 	filteringConfig, err := adg.FilteringStatus()
 	if err != nil {
@@ -486,8 +712,10 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	if diags.HasError() {
 		return
 	}
+}
 
-	// SAFE BROWSING
+// readSafeBrowsing - reads the safe browsing status from AdGuard Home into the config model
+func (o *configCommonModel) readSafeBrowsing(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string) {
 	// get refreshed safe browsing status from AdGuard Home
 	safeBrowsingStatus, err := adg.SafeBrowsingStatus()
 	if err != nil {
@@ -504,8 +732,10 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	})
 	// add to config model
 	o.SafeBrowsing = types.BoolValue(*&safeBrowsingStatus.Enabled)
+}
 
-	// PARENTAL CONTROL
+// readParentalControl - reads the parental control status from AdGuard Home into the config model
+func (o *configCommonModel) readParentalControl(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string) {
 	// get refreshed safe parental control status from AdGuard Home
 	parentalStatus, err := adg.ParentalStatus()
 	if err != nil {
@@ -521,8 +751,13 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	})
 	// add to config model
 	o.ParentalControl = types.BoolValue(*&parentalStatus.Enabled)
+}
+
+// readSafeSearch - reads the safe search config from AdGuard Home into the config model
+func (o *configCommonModel) readSafeSearch(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string) {
+	// initialize empty diags variable
+	var d diag.Diagnostics
 
-	// SAFE SEARCH
 	// retrieve safe search info
 	safeSearchConfig, err := adg.SafeSearchStatus()
 	if err != nil {
@@ -562,8 +797,13 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	if diags.HasError() {
 		return
 	}
+}
+
+// readQueryLog - reads the query log config from AdGuard Home into the config model
+func (o *configCommonModel) readQueryLog(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string) {
+	// initialize empty diags variable
+	var d diag.Diagnostics
 
-	// QUERY LOG
 	// retrieve query log config info
 	queryLogConfig, err := adg.QuerylogConfig()
 	if err != nil {
@@ -592,13 +832,13 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	stateQueryLogConfig.Interval = types.Int64Value(int64(queryLogConfig.Interval / 1000 / 3600))
 	stateQueryLogConfig.AnonymizeClientIp = types.BoolValue(queryLogConfig.AnonymizeClientIp)
 	if len(queryLogConfig.Ignored) > 0 {
-		stateQueryLogConfig.Ignored, d = types.SetValueFrom(ctx, types.StringType, queryLogConfig.Ignored)
+		stateQueryLogConfig.Ignored, d = types.SetValueFrom(ctx, domainType{}, queryLogConfig.Ignored)
 		diags.Append(d...)
 		if diags.HasError() {
 			return
 		}
 	} else {
-		stateQueryLogConfig.Ignored = types.SetValueMust(types.StringType, []attr.Value{})
+		stateQueryLogConfig.Ignored = types.SetValueMust(domainType{}, []attr.Value{})
 	}
 	stateQueryLogConfig.IgnoredEnabled = types.BoolValue(queryLogConfig.IgnoredEnabled)
 	// add to config model
@@ -607,8 +847,13 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	if diags.HasError() {
 		return
 	}
+}
+
+// readStats - reads the server statistics config from AdGuard Home into the config model
+func (o *configCommonModel) readStats(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string) {
+	// initialize empty diags variable
+	var d diag.Diagnostics
 
-	// STATS
 	// retrieve server statistics config info
 	statsConfig, err := adg.StatsConfig()
 	if err != nil {
@@ -636,13 +881,13 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	stateStatsConfig.Enabled = types.BoolValue(statsConfig.Enabled)
 	stateStatsConfig.Interval = types.Int64Value(int64(statsConfig.Interval / 3600 / 1000))
 	if len(statsConfig.Ignored) > 0 {
-		stateStatsConfig.Ignored, d = types.SetValueFrom(ctx, types.StringType, statsConfig.Ignored)
+		stateStatsConfig.Ignored, d = types.SetValueFrom(ctx, domainType{}, statsConfig.Ignored)
 		diags.Append(d...)
 		if diags.HasError() {
 			return
 		}
 	} else {
-		stateStatsConfig.Ignored = types.SetValueMust(types.StringType, []attr.Value{})
+		stateStatsConfig.Ignored = types.SetValueMust(domainType{}, []attr.Value{})
 	}
 	stateStatsConfig.IgnoredEnabled = types.BoolValue(statsConfig.IgnoredEnabled)
 	// add to config model
@@ -651,8 +896,13 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	if diags.HasError() {
 		return
 	}
+}
+
+// readBlockedServices - reads the blocked services and their pause schedule from AdGuard Home into the config model
+func (o *configCommonModel) readBlockedServices(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string) {
+	// initialize empty diags variable
+	var d diag.Diagnostics
 
-	// BLOCKED SERVICES
 	// get refreshed blocked services from AdGuard Home
 	blockedServicesPauseSchedule, err := adg.BlockedServicesGet()
 	if err != nil {
@@ -725,8 +975,13 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	if diags.HasError() {
 		return
 	}
+}
+
+// readDns - reads the DNS config and access list from AdGuard Home into the config model
+func (o *configCommonModel) readDns(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string) {
+	// initialize empty diags variable
+	var d diag.Diagnostics
 
-	// DNS CONFIG
 	dnsConfig, err := adg.DnsInfo()
 	if err != nil {
 		diags.AddError(
@@ -825,44 +1080,67 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	stateDnsConfig.UpstreamTimeout = types.Int64Value(int64(dnsConfig.UpstreamTimeout))
 
 	// DNS ACCESS
-	// retrieve dns access info
-	dnsAccess, err := adg.AccessList()
-	if err != nil {
-		diags.AddError(
-			"Unable to Read AdGuard Home Config",
-			err.Error(),
-		)
-		return
-	}
-	// convert to JSON for response logging
-	dnsAccessJson, err := json.Marshal(dnsAccess)
-	if err != nil {
-		diags.AddError(
-			"Unable to Parse AdGuard Home Config",
-			err.Error(),
-		)
-		return
-	}
-	// log response body
-	tflog.Debug(ctx, "ADG API response", map[string]interface{}{
-		"object": "dnsAccess",
-		"body":   string(dnsAccessJson),
-	})
-	stateDnsConfig.AllowedClients, d = types.SetValueFrom(ctx, types.StringType, dnsAccess.AllowedClients)
-	diags.Append(d...)
-	if diags.HasError() {
-		return
-	}
-	stateDnsConfig.DisallowedClients, d = types.SetValueFrom(ctx, types.StringType, dnsAccess.DisallowedClients)
-	diags.Append(d...)
-	if diags.HasError() {
-		return
+	// the access list may be owned by standalone `adguard_access_rule` resources instead
+	manageAccessList := CONFIG_DNS_MANAGE_ACCESS_LIST
+	if rtype == "resource" && !currState.Dns.IsNull() {
+		var currStateDnsConfig dnsConfigModel
+		d = currState.Dns.As(ctx, &currStateDnsConfig, basetypes.ObjectAsOptions{})
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+		if !currStateDnsConfig.ManageAccessList.IsNull() {
+			manageAccessList = currStateDnsConfig.ManageAccessList.ValueBool()
+		}
 	}
-	stateDnsConfig.BlockedHosts, d = types.SetValueFrom(ctx, types.StringType, dnsAccess.BlockedHosts)
-	diags.Append(d...)
-	if diags.HasError() {
-		return
+	stateDnsConfig.ManageAccessList = types.BoolValue(manageAccessList)
+
+	if rtype != "resource" || manageAccessList {
+		// retrieve dns access info
+		dnsAccess, err := adg.AccessList()
+		if err != nil {
+			diags.AddError(
+				"Unable to Read AdGuard Home Config",
+				err.Error(),
+			)
+			return
+		}
+		// convert to JSON for response logging
+		dnsAccessJson, err := json.Marshal(dnsAccess)
+		if err != nil {
+			diags.AddError(
+				"Unable to Parse AdGuard Home Config",
+				err.Error(),
+			)
+			return
+		}
+		// log response body
+		tflog.Debug(ctx, "ADG API response", map[string]interface{}{
+			"object": "dnsAccess",
+			"body":   string(dnsAccessJson),
+		})
+		stateDnsConfig.AllowedClients, d = types.SetValueFrom(ctx, clientIdType, dnsAccess.AllowedClients)
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+		stateDnsConfig.DisallowedClients, d = types.SetValueFrom(ctx, clientIdType, dnsAccess.DisallowedClients)
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+		stateDnsConfig.BlockedHosts, d = types.SetValueFrom(ctx, domainType{}, dnsAccess.BlockedHosts)
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+	} else {
+		// use null sets when the access list is not managed by this resource
+		stateDnsConfig.AllowedClients = types.SetNull(clientIdType)
+		stateDnsConfig.DisallowedClients = types.SetNull(clientIdType)
+		stateDnsConfig.BlockedHosts = types.SetNull(domainType{})
 	}
+
 	// add to config model
 	o.Dns, d = types.ObjectValueFrom(ctx, dnsConfigModel{}.attrTypes(), &stateDnsConfig)
 	diags.Append(d...)
@@ -870,7 +1148,21 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 		return
 	}
 
-	// DHCP
+	if rtype != "resource" {
+		// the ownership toggle only exists in the resource schema
+		o.Dns, d = withoutAttributes(ctx, o.Dns, "manage_access_list")
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+	}
+}
+
+// readDhcp - reads the DHCP config and static leases from AdGuard Home into the config model
+func (o *configCommonModel) readDhcp(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string) {
+	// initialize empty diags variable
+	var d diag.Diagnostics
+
 	// retrieve dhcp info
 	dhcpStatus, err := adg.DhcpStatus()
 	if err != nil {
@@ -903,7 +1195,7 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	stateDhcpIpv4Config.LeaseDuration = types.Int64Value(int64(dhcpStatus.V4.LeaseDuration))
 
 	var stateDhcpIpv6Config dhcpIpv6Model
-	stateDhcpIpv6Config.RangeStart = types.StringValue(dhcpStatus.V6.RangeStart)
+	stateDhcpIpv6Config.RangeStart = newNetworkAddressValue(ipAddressType, dhcpStatus.V6.RangeStart)
 	stateDhcpIpv6Config.LeaseDuration = types.Int64Value(int64(dhcpStatus.V6.LeaseDuration))
 
 	// now parse the top nested attribute
@@ -922,13 +1214,28 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	if diags.HasError() {
 		return
 	}
-	if len(dhcpStatus.StaticLeases) > 0 {
+	// static leases may be owned by standalone `adguard_dhcp_static_lease` resources instead
+	manageStaticLeases := CONFIG_DHCP_MANAGE_STATIC_LEASES
+	if rtype == "resource" && !currState.Dhcp.IsNull() {
+		var currStateDhcpConfig dhcpConfigModel
+		d = currState.Dhcp.As(ctx, &currStateDhcpConfig, basetypes.ObjectAsOptions{})
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+		if !currStateDhcpConfig.ManageStaticLeases.IsNull() {
+			manageStaticLeases = currStateDhcpConfig.ManageStaticLeases.ValueBool()
+		}
+	}
+	stateDhcpConfig.ManageStaticLeases = types.BoolValue(manageStaticLeases)
+
+	if len(dhcpStatus.StaticLeases) > 0 && (rtype != "resource" || manageStaticLeases) {
 		// need to go through all entries to create a slice
 		var dhcpStaticLeases []dhcpStaticLeasesModel
 		var stateDhcpConfigStaticLease dhcpStaticLeasesModel
 		for _, dhcpStaticLease := range dhcpStatus.StaticLeases {
-			stateDhcpConfigStaticLease.Mac = types.StringValue(dhcpStaticLease.Mac)
-			stateDhcpConfigStaticLease.Ip = types.StringValue(dhcpStaticLease.Ip)
+			stateDhcpConfigStaticLease.Mac = newNetworkAddressValue(macAddressType, dhcpStaticLease.Mac)
+			stateDhcpConfigStaticLease.Ip = newNetworkAddressValue(ipAddressType, dhcpStaticLease.Ip)
 			stateDhcpConfigStaticLease.Hostname = types.StringValue(dhcpStaticLease.Hostname)
 			dhcpStaticLeases = append(dhcpStaticLeases, stateDhcpConfigStaticLease)
 		}
@@ -939,7 +1246,7 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 			return
 		}
 	} else {
-		// use a null set
+		// use a null set, which is also the case when static leases are not managed by this resource
 		stateDhcpConfig.StaticLeases = types.SetNull(types.ObjectType{AttrTypes: dhcpStaticLeasesModel{}.attrTypes()})
 	}
 
@@ -986,8 +1293,13 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 			return
 		}
 	}
+}
+
+// readTls - reads the TLS config from AdGuard Home into the config model
+func (o *configCommonModel) readTls(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string) {
+	// initialize empty diags variable
+	var d diag.Diagnostics
 
-	// TLS
 	// get refreshed filtering config value from AdGuard Home
 	tlsConfig, err := adg.TlsStatus()
 	if err != nil {
@@ -1066,6 +1378,23 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	stateTlsConfig.WarningValidation = types.StringValue(tlsConfig.WarningValidation)
 	stateTlsConfig.ValidPair = types.BoolValue(tlsConfig.ValidPair)
 	stateTlsConfig.ServePlainDns = types.BoolValue(tlsConfig.ServePlainDns)
+	// the plan-time validation settings do not exist in AdGuard Home, so keep them from the current state
+	stateTlsConfig.ExpiryWarningDays = types.Int64Value(CONFIG_TLS_EXPIRY_WARNING_DAYS)
+	stateTlsConfig.ValidateWithServer = types.BoolValue(CONFIG_TLS_VALIDATE_WITH_SERVER)
+	if rtype == "resource" && !currState.Tls.IsNull() {
+		var currStateTlsConfig tlsConfigModel
+		d = currState.Tls.As(ctx, &currStateTlsConfig, basetypes.ObjectAsOptions{})
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+		if !currStateTlsConfig.ExpiryWarningDays.IsNull() {
+			stateTlsConfig.ExpiryWarningDays = currStateTlsConfig.ExpiryWarningDays
+		}
+		if !currStateTlsConfig.ValidateWithServer.IsNull() {
+			stateTlsConfig.ValidateWithServer = currStateTlsConfig.ValidateWithServer
+		}
+	}
 
 	// add to config model
 	o.Tls, d = types.ObjectValueFrom(ctx, tlsConfigModel{}.attrTypes(), &stateTlsConfig)
@@ -1074,7 +1403,18 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 		return
 	}
 
-	// REWRITES
+	if rtype != "resource" {
+		// the plan-time validation settings only exist in the resource schema
+		o.Tls, d = withoutAttributes(ctx, o.Tls, "expiry_warning_days", "validate_with_server")
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+	}
+}
+
+// readRewrites - reads the DNS rewrites status from AdGuard Home into the config model
+func (o *configCommonModel) readRewrites(ctx context.Context, adg adguard.ADG, currState *configCommonModel, diags *diag.Diagnostics, rtype string) {
 	// get refreshed rewrite status from AdGuard Home
 	rewriteSettings, err := adg.RewriteSettings()
 	if err != nil {
@@ -1091,16 +1431,14 @@ func (o *configCommonModel) Read(ctx context.Context, adg adguard.ADG, currState
 	})
 	// add to config model
 	o.Rewrites = types.BoolValue(rewriteSettings.Enabled)
-
-	// if we got here, all went fine
 }
 
-// common `Create` and `Update` function for the resource
-func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
-	// initialize empty diags variable
+// updateFiltering - sets the filtering config in AdGuard Home using the plan
+func (r *configResource) updateFiltering(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
+	// initialize empty diags and error variables
 	var d diag.Diagnostics
+	var err error
 
-	// FILTERING CONFIG
 	// unpack nested attributes from plan
 	var planFiltering filteringModel
 	d = plan.Filtering.As(ctx, &planFiltering, basetypes.ObjectAsOptions{})
@@ -1115,7 +1453,7 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 	filteringConfig.Interval = uint(planFiltering.UpdateInterval.ValueInt64())
 
 	// set filtering config using plan
-	err := r.adg.FilteringConfig(filteringConfig)
+	err = r.adg.FilteringConfig(filteringConfig)
 	if err != nil {
 		diags.AddError(
 			"Unable to Update AdGuard Home Config",
@@ -1123,8 +1461,13 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 		)
 		return
 	}
+}
+
+// updateSafeBrowsing - sets the safe browsing status in AdGuard Home using the plan
+func (r *configResource) updateSafeBrowsing(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
+	// initialize empty error variable
+	var err error
 
-	// SAFE BROWSING
 	// set safe browsing status using plan
 	if plan.SafeBrowsing.ValueBool() {
 		err = r.adg.SafeBrowsingEnable()
@@ -1138,8 +1481,13 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 		)
 		return
 	}
+}
+
+// updateParentalControl - sets the parental control status in AdGuard Home using the plan
+func (r *configResource) updateParentalControl(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
+	// initialize empty error variable
+	var err error
 
-	// PARENTAL CONTROL
 	// set parental control status using plan
 	if plan.ParentalControl.ValueBool() {
 		err = r.adg.ParentalEnable()
@@ -1153,8 +1501,14 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 		)
 		return
 	}
+}
+
+// updateSafeSearch - sets the safe search config in AdGuard Home using the plan
+func (r *configResource) updateSafeSearch(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
+	// initialize empty diags and error variables
+	var d diag.Diagnostics
+	var err error
 
-	// SAFE SEARCH
 	// unpack nested attributes from plan
 	var planSafeSearch safeSearchModel
 	d = plan.SafeSearch.As(ctx, &planSafeSearch, basetypes.ObjectAsOptions{})
@@ -1187,8 +1541,14 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 		)
 		return
 	}
+}
+
+// updateQueryLog - sets the query log config in AdGuard Home using the plan
+func (r *configResource) updateQueryLog(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
+	// initialize empty diags and error variables
+	var d diag.Diagnostics
+	var err error
 
-	// QUERY LOG
 	// unpack nested attributes from plan
 	var planQueryLogConfig queryLogConfigModel
 	d = plan.QueryLog.As(ctx, &planQueryLogConfig, basetypes.ObjectAsOptions{})
@@ -1218,8 +1578,14 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 		)
 		return
 	}
+}
+
+// updateStats - sets the server statistics config in AdGuard Home using the plan
+func (r *configResource) updateStats(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
+	// initialize empty diags and error variables
+	var d diag.Diagnostics
+	var err error
 
-	// STATS
 	// unpack nested attributes from plan
 	var planStatsConfig statsConfigModel
 	d = plan.Stats.As(ctx, &planStatsConfig, basetypes.ObjectAsOptions{})
@@ -1247,8 +1613,14 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 		)
 		return
 	}
+}
+
+// updateBlockedServices - sets the blocked services and their pause schedule in AdGuard Home using the plan
+func (r *configResource) updateBlockedServices(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
+	// initialize empty diags and error variables
+	var d diag.Diagnostics
+	var err error
 
-	// BLOCKED SERVICES
 	// instantiate empty object for storing plan data
 	var blockedServices []string
 	// populate blocked services from plan
@@ -1287,8 +1659,14 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 		)
 		return
 	}
+}
+
+// updateDns - sets the DNS config and access list in AdGuard Home using the plan
+func (r *configResource) updateDns(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
+	// initialize empty diags and error variables
+	var d diag.Diagnostics
+	var err error
 
-	// DNS CONFIG
 	// unpack nested attributes from plan
 	var planDnsConfig dnsConfigModel
 	d = plan.Dns.As(ctx, &planDnsConfig, basetypes.ObjectAsOptions{})
@@ -1376,6 +1754,11 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 		return
 	}
 
+	// the access list is left alone when managed by other resources
+	if !planDnsConfig.ManageAccessList.IsNull() && !planDnsConfig.ManageAccessList.ValueBool() {
+		return
+	}
+
 	// instantiate empty dns access list for storing plan data
 	var dnsAccess adgmodels.AccessList
 	// populate dns access list from plan
@@ -1409,8 +1792,14 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 		)
 		return
 	}
+}
+
+// updateDhcp - sets the DHCP config and static leases in AdGuard Home using the plan
+func (r *configResource) updateDhcp(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
+	// initialize empty diags and error variables
+	var d diag.Diagnostics
+	var err error
 
-	// DHCP
 	// unpack nested attributes from plan
 	var planDhcpConfig dhcpConfigModel
 	d = plan.Dhcp.As(ctx, &planDhcpConfig, basetypes.ObjectAsOptions{})
@@ -1522,7 +1911,8 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 	}
 
 	// only work on state dhcp leases if the dhcp server is configured appropriately
-	if dhcpConfig.InterfaceName != "" {
+	// and static leases are managed by this resource
+	if dhcpConfig.InterfaceName != "" && planDhcpConfig.ManageStaticLeases.ValueBool() {
 		// go through the dhcp static leases existing in state
 		for _, stateDhcpStaticLease := range stateDhcpStaticLeases {
 			// instantiate empty object for storing state data
@@ -1548,7 +1938,7 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 		}
 	}
 
-	// go through all dhcp static leases in plan
+	// go through all dhcp static leases in plan, which will be empty when static leases are not managed by this resource
 	for _, planDhcpStaticLease := range planDhcpStaticLeases {
 		// instantiate empty object for storing plan data
 		var dhcpStaticLease adgmodels.DhcpStaticLease
@@ -1569,8 +1959,13 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 			}
 		}
 	}
+}
+
+// updateTls - sets the TLS config in AdGuard Home using the plan
+func (r *configResource) updateTls(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
+	// initialize empty diags variable
+	var d diag.Diagnostics
 
-	// TLS CONFIG
 	// unpack nested attributes from plan
 	var planTlsConfig tlsConfigModel
 	d = plan.Tls.As(ctx, &planTlsConfig, basetypes.ObjectAsOptions{})
@@ -1589,26 +1984,8 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 	tlsConfig.PortDnsOverQuic = uint16(planTlsConfig.PortDnsOverQuic.ValueInt64())
 	tlsConfig.ServePlainDns = planTlsConfig.ServePlainDns.ValueBool()
 
-	// regex to match a file path
-	var filePathIdentifier = regexp.MustCompile(`^/\w|\w:`)
-
-	// check what is the certificate chain
-	if len(planTlsConfig.CertificateChain.ValueString()) > 0 && filePathIdentifier.MatchString(planTlsConfig.CertificateChain.ValueString()[0:2]) {
-		// it's a file path
-		tlsConfig.CertificatePath = planTlsConfig.CertificateChain.ValueString()
-	} else {
-		// it's the base64 PEM file
-		tlsConfig.CertificateChain = planTlsConfig.CertificateChain.ValueString()
-	}
-
-	// check what is the private key
-	if len(planTlsConfig.PrivateKey.ValueString()) > 0 && filePathIdentifier.MatchString(planTlsConfig.PrivateKey.ValueString()[0:2]) {
-		// it's a file path
-		tlsConfig.PrivateKeyPath = planTlsConfig.PrivateKey.ValueString()
-	} else {
-		// it's the base64 PEM file
-		tlsConfig.PrivateKey = planTlsConfig.PrivateKey.ValueString()
-	}
+	// the certificate chain and private key are either file paths or base64 PEM files
+	setTlsCertificateAndKey(&tlsConfig, planTlsConfig.CertificateChain.ValueString(), planTlsConfig.PrivateKey.ValueString())
 
 	// set tls config using plan
 	tlsConfigResponse, err := r.adg.TlsConfigure(tlsConfig)
@@ -1644,8 +2021,13 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 	if diags.HasError() {
 		return
 	}
+}
+
+// updateRewrites - sets the DNS rewrites status in AdGuard Home using the plan
+func (r *configResource) updateRewrites(ctx context.Context, plan *configCommonModel, state *configCommonModel, diags *diag.Diagnostics) {
+	// initialize empty error variable
+	var err error
 
-	// REWRITES
 	// instantiate empty object for storing plan data
 	var rewriteSettings adgmodels.RewriteSettings
 	// populate rewrite settings from plan
@@ -1660,6 +2042,15 @@ func (r *configResource) CreateOrUpdate(ctx context.Context, plan *configCommonM
 		)
 		return
 	}
+}
 
-	// if we got here, all went fine
+// withoutAttributes - returns a copy of an object without the given attributes, which only exist in the resource schema
+func withoutAttributes(ctx context.Context, object types.Object, names ...string) (types.Object, diag.Diagnostics) {
+	attrTypes := object.AttributeTypes(ctx)
+	attrs := object.Attributes()
+	for _, name := range names {
+		delete(attrTypes, name)
+		delete(attrs, name)
+	}
+	return types.ObjectValue(attrTypes, attrs)
 }
@@ -82,7 +82,7 @@ func (d *configDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					},
 					"ignored": schema.SetAttribute{
 						Description: "Set of host names which should not be written to log",
-						ElementType: types.StringType,
+						ElementType: domainType{},
 						Computed:    true,
 					},
 					"ignored_enabled": schema.BoolAttribute{
@@ -104,7 +104,7 @@ func (d *configDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					},
 					"ignored": schema.SetAttribute{
 						Description: "Set of host names which should not be counted in the server statistics",
-						ElementType: types.StringType,
+						ElementType: domainType{},
 						Computed:    true,
 					},
 					"ignored_enabled": schema.BoolAttribute{
@@ -237,17 +237,17 @@ func (d *configDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					},
 					"allowed_clients": schema.SetAttribute{
 						Description: "The allowlist of clients: IP addresses, CIDRs, or ClientIDs",
-						ElementType: types.StringType,
+						ElementType: clientIdType,
 						Computed:    true,
 					},
 					"disallowed_clients": schema.SetAttribute{
 						Description: "The blocklist of clients: IP addresses, CIDRs, or ClientIDs",
-						ElementType: types.StringType,
+						ElementType: clientIdType,
 						Computed:    true,
 					},
 					"blocked_hosts": schema.SetAttribute{
 						Description: "Disallowed domains",
-						ElementType: types.StringType,
+						ElementType: domainType{},
 						Computed:    true,
 					},
 				},
@@ -293,6 +293,7 @@ func (d *configDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Attributes: map[string]schema.Attribute{
 							"range_start": schema.StringAttribute{
 								Description: "The start range for the DHCP server scope",
+								CustomType:  ipAddressType,
 								Computed:    true,
 							},
 							"lease_duration": schema.Int64Attribute{
@@ -332,10 +333,12 @@ func (d *configDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 							Attributes: map[string]schema.Attribute{
 								"mac": schema.StringAttribute{
 									Description: "MAC address associated with the static lease",
+									CustomType:  macAddressType,
 									Computed:    true,
 								},
 								"ip": schema.StringAttribute{
 									Description: "IP address associated with the static lease",
+									CustomType:  ipAddressType,
 									Computed:    true,
 								},
 								"hostname": schema.StringAttribute{
@@ -455,7 +458,7 @@ func (d *configDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	// use common model for state
 	var newState configCommonModel
 	// use common Read function
-	newState.Read(ctx, *d.adg, &state, &resp.Diagnostics, "datasource")
+	newState.Read(ctx, *d.adg, &state, &resp.Diagnostics, "datasource", false)
 	if resp.Diagnostics.HasError() {
 		return
 	}
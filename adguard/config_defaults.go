@@ -31,16 +31,21 @@ const CONFIG_DNS_UPSTREAM_MODE = "load_balance"
 const CONFIG_DNS_USE_PRIVATE_PTR_RESOLVERS = false
 const CONFIG_DNS_RESOLVE_CLIENTS = true
 const CONFIG_DNS_UPSTREAM_TIMEOUT = 10
+const CONFIG_DNS_MANAGE_ACCESS_LIST = true
 const CONFIG_DHCP_ENABLED = false
 const CONFIG_DHCP_V4_LEASE_DURATION = 0     // seconds
 const CONFIG_DHCP_V6_LEASE_DURATION = 86400 // seconds
+const CONFIG_DHCP_MANAGE_STATIC_LEASES = true
 const CONFIG_TLS_ENABLED = false
 const CONFIG_TLS_FORCE_HTTPS = false
 const CONFIG_TLS_PORT_HTTPS = 443
 const CONFIG_TLS_PORT_DNS_OVER_TLS = 853
 const CONFIG_TLS_PORT_DNS_OVER_QUIC = 853
 const CONFIG_TLS_SERVE_PLAIN_DNS = true
+const CONFIG_TLS_EXPIRY_WARNING_DAYS = 30
+const CONFIG_TLS_VALIDATE_WITH_SERVER = false
 const CONFIG_REWRITES_ENABLED = true
+const CONFIG_PARTIAL_OWNERSHIP = false
 
 var CONFIG_DNS_BOOTSTRAP = []string{"9.9.9.10", "149.112.112.10", "2620:fe::10", "2620:fe::fe:10"}
 var CONFIG_DNS_UPSTREAM = []string{"https://dns10.quad9.net/dns-query"}
@@ -2,6 +2,7 @@ package adguard
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"time"
@@ -27,13 +28,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // ensure the implementation satisfies the expected interfaces
 var (
-	_ resource.Resource                = &configResource{}
-	_ resource.ResourceWithConfigure   = &configResource{}
-	_ resource.ResourceWithImportState = &configResource{}
+	_ resource.Resource                     = &configResource{}
+	_ resource.ResourceWithConfigure        = &configResource{}
+	_ resource.ResourceWithImportState      = &configResource{}
+	_ resource.ResourceWithConfigValidators = &configResource{}
 )
 
 // configResource is the resource implementation
@@ -41,6 +44,13 @@ type configResource struct {
 	adg *adguard.ADG
 }
 
+// configResourceModel maps config resource schema data
+type configResourceModel struct {
+	configCommonModel
+	PartialOwnership types.Bool   `tfsdk:"partial_ownership"`
+	OnDestroy        types.String `tfsdk:"on_destroy"`
+}
+
 // NewConfigResource is a helper function to simplify the provider implementation
 func NewConfigResource() resource.Resource {
 	return &configResource{}
@@ -130,13 +140,14 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					},
 					"ignored": schema.SetAttribute{
 						Description: "Set of host names which should not be written to log",
-						ElementType: types.StringType,
+						ElementType: domainType{},
 						Computed:    true,
 						Optional:    true,
 						Validators: []validator.Set{
 							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(checkDomain()),
 						},
-						Default: setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+						Default: setdefault.StaticValue(types.SetValueMust(domainType{}, []attr.Value{})),
 					},
 					"ignored_enabled": schema.BoolAttribute{
 						Description: fmt.Sprintf("If `true`, the host names in the `ignored` array are excluded from the query log. Defaults to `%t`", CONFIG_QUERYLOG_IGNORED_ENABLED),
@@ -167,13 +178,14 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					},
 					"ignored": schema.SetAttribute{
 						Description: "Set of host names which should not be counted in the server statistics",
-						ElementType: types.StringType,
+						ElementType: domainType{},
 						Computed:    true,
 						Optional:    true,
 						Validators: []validator.Set{
 							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(checkDomain()),
 						},
-						Default: setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+						Default: setdefault.StaticValue(types.SetValueMust(domainType{}, []attr.Value{})),
 					},
 					"ignored_enabled": schema.BoolAttribute{
 						Description: fmt.Sprintf("If `true`, the host names in the `ignored` array are excluded from the statistics. Defaults to `%t`", CONFIG_STATS_IGNORED_ENABLED),
@@ -207,7 +219,7 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						ElementType: types.StringType,
 						Computed:    true,
 						Optional:    true,
-						Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+						Validators:  []validator.List{listvalidator.SizeAtLeast(1), checkBootstrapDns()},
 						Default: listdefault.StaticValue(
 							types.ListValueMust(types.StringType, convertToAttr(CONFIG_DNS_BOOTSTRAP)),
 						),
@@ -217,7 +229,7 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						ElementType: types.StringType,
 						Computed:    true,
 						Optional:    true,
-						Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+						Validators:  []validator.List{listvalidator.SizeAtLeast(1), checkUpstreamDns()},
 						Default: listdefault.StaticValue(
 							types.ListValueMust(types.StringType, convertToAttr(CONFIG_DNS_UPSTREAM)),
 						),
@@ -227,7 +239,7 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						ElementType: types.StringType,
 						Optional:    true,
 						Computed:    true,
-						Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+						Validators:  []validator.List{listvalidator.SizeAtLeast(1), checkUpstreamDns()},
 						Default: listdefault.StaticValue(
 							types.ListNull(types.StringType),
 						),
@@ -424,7 +436,7 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						ElementType: types.StringType,
 						Computed:    true,
 						Optional:    true,
-						Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+						Validators:  []validator.Set{setvalidator.SizeAtLeast(1), checkUpstreamDns()},
 						Default: setdefault.StaticValue(
 							types.SetValueMust(types.StringType, []attr.Value{}),
 						),
@@ -437,17 +449,17 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					},
 					"allowed_clients": schema.SetAttribute{
 						Description: "The allowlist of clients: IP addresses, CIDRs, or ClientIDs",
-						ElementType: types.StringType,
+						ElementType: clientIdType,
 						Optional:    true,
 						Computed:    true,
 						Default: setdefault.StaticValue(
-							types.SetNull(types.StringType),
+							types.SetNull(clientIdType),
 						),
 						Validators: []validator.Set{
 							setvalidator.SizeAtLeast(1),
 							setvalidator.ValueStringsAre(
 								stringvalidator.RegexMatches(
-									regexp.MustCompile(`^[a-z0-9/.:-]+$`),
+									regexp.MustCompile(`^([a-z0-9/.:-]+|[0-9A-Fa-f/.:]+)$`),
 									"must be an IP address/CIDR or only contain numbers, lowercase letters, and hyphens",
 								),
 							),
@@ -455,11 +467,11 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					},
 					"disallowed_clients": schema.SetAttribute{
 						Description: "The blocklist of clients: IP addresses, CIDRs, or ClientIDs",
-						ElementType: types.StringType,
+						ElementType: clientIdType,
 						Optional:    true,
 						Computed:    true,
 						Default: setdefault.StaticValue(
-							types.SetNull(types.StringType),
+							types.SetNull(clientIdType),
 						),
 						Validators: []validator.Set{
 							setvalidator.All(
@@ -470,7 +482,7 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							),
 							setvalidator.ValueStringsAre(
 								stringvalidator.RegexMatches(
-									regexp.MustCompile(`^[a-z0-9/.:-]+$`),
+									regexp.MustCompile(`^([a-z0-9/.:-]+|[0-9A-Fa-f/.:]+)$`),
 									"must be an IP address/CIDR or only contain numbers, lowercase letters, and hyphens",
 								),
 							),
@@ -478,14 +490,26 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					},
 					"blocked_hosts": schema.SetAttribute{
 						Description: "Disallowed domains. Defaults to the ones supplied by the default AdGuard Home configuration",
-						ElementType: types.StringType,
+						ElementType: domainType{},
 						Optional:    true,
 						Computed:    true,
-						Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(checkDomain()),
+						},
 						Default: setdefault.StaticValue(
-							types.SetValueMust(types.StringType, convertToAttr(CONFIG_DNS_BLOCKED_HOSTS)),
+							types.SetValueMust(domainType{}, convertToDomainAttr(CONFIG_DNS_BLOCKED_HOSTS)),
 						),
 					},
+					"manage_access_list": schema.BoolAttribute{
+						Description: fmt.Sprintf("Whether this resource manages the DNS access list (`allowed_clients`, `disallowed_clients` and `blocked_hosts`). Must be set to `false` when the access list is managed with `adguard_access_rule` resources. Defaults to `%t`", CONFIG_DNS_MANAGE_ACCESS_LIST),
+						Computed:    true,
+						Optional:    true,
+						Default:     booldefault.StaticBool(CONFIG_DNS_MANAGE_ACCESS_LIST),
+						Validators: []validator.Bool{
+							checkAccessList(),
+						},
+					},
 				},
 			},
 			"dhcp": schema.SingleNestedAttribute{
@@ -576,6 +600,7 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						Attributes: map[string]schema.Attribute{
 							"range_start": schema.StringAttribute{
 								Description: "The start range for the DHCP server scope",
+								CustomType:  ipAddressType,
 								Required:    true,
 								Validators: []validator.String{
 									stringvalidator.RegexMatches(
@@ -614,16 +639,18 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							Attributes: map[string]schema.Attribute{
 								"mac": schema.StringAttribute{
 									Description: "MAC address associated with the static lease",
+									CustomType:  macAddressType,
 									Required:    true,
 									Validators: []validator.String{
 										stringvalidator.RegexMatches(
-											regexp.MustCompile(`^[a-f0-9:]+$`),
+											regexp.MustCompile(`^([0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}$`),
 											"must be a valid MAC address",
 										),
 									},
 								},
 								"ip": schema.StringAttribute{
 									Description: "IP address associated with the static lease",
+									CustomType:  ipAddressType,
 									Required:    true,
 									Validators: []validator.String{
 										stringvalidator.RegexMatches(
@@ -645,6 +672,15 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							},
 						},
 					},
+					"manage_static_leases": schema.BoolAttribute{
+						Description: fmt.Sprintf("Whether this resource manages the DHCP static leases. Set to `false` when static leases are managed with `adguard_dhcp_static_lease` resources. Defaults to `%t`", CONFIG_DHCP_MANAGE_STATIC_LEASES),
+						Computed:    true,
+						Optional:    true,
+						Default:     booldefault.StaticBool(CONFIG_DHCP_MANAGE_STATIC_LEASES),
+						Validators: []validator.Bool{
+							checkStaticLeases(),
+						},
+					},
 				},
 			},
 			"tls": schema.SingleNestedAttribute{
@@ -681,6 +717,9 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						Computed:    true,
 						Optional:    true,
 						Default:     int64default.StaticInt64(CONFIG_TLS_PORT_HTTPS),
+						Validators: []validator.Int64{
+							checkTlsPorts(),
+						},
 					},
 					"port_dns_over_tls": schema.Int64Attribute{
 						Description: fmt.Sprintf("The DNS-over-TLS (DoT) port. Set to `0` to disable. Defaults to `%d`", CONFIG_TLS_PORT_DNS_OVER_TLS),
@@ -752,6 +791,21 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							checkDnsEncryption(),
 						},
 					},
+					"expiry_warning_days": schema.Int64Attribute{
+						Description: fmt.Sprintf("Number of days before the expiry of an inline certificate from which a warning is issued during plan. Set to `0` to disable. Defaults to `%d`", CONFIG_TLS_EXPIRY_WARNING_DAYS),
+						Computed:    true,
+						Optional:    true,
+						Default:     int64default.StaticInt64(CONFIG_TLS_EXPIRY_WARNING_DAYS),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"validate_with_server": schema.BoolAttribute{
+						Description: fmt.Sprintf("When `true`, the certificate chain and private key are validated by AdGuard Home during plan whenever they or the server name change, without being applied. Defaults to `%t`", CONFIG_TLS_VALIDATE_WITH_SERVER),
+						Computed:    true,
+						Optional:    true,
+						Default:     booldefault.StaticBool(CONFIG_TLS_VALIDATE_WITH_SERVER),
+					},
 				},
 			},
 			"rewrites": schema.BoolAttribute{
@@ -760,10 +814,24 @@ func (r *configResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Default:     booldefault.StaticBool(CONFIG_REWRITES_ENABLED),
 			},
+			"partial_ownership": schema.BoolAttribute{
+				Description: fmt.Sprintf("When `true`, only the sections configured in this resource are managed. Sections left out are neither read nor updated, and are not reset on destroy. When `false`, sections left out are managed with their default values. Defaults to `%t`, so existing configurations keep managing all sections unless they opt in", CONFIG_PARTIAL_OWNERSHIP),
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(CONFIG_PARTIAL_OWNERSHIP),
+			},
+			"on_destroy": onDestroyResourceSchema(),
 		},
 	}
 }
 
+// ConfigValidators validates values that depend on multiple attributes
+func (r *configResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		checkDhcpConfig(),
+	}
+}
+
 // ModifyPlan allows for validating plan values with dynamic options
 func (r *configResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 	// if plan is null, then there is no plan to work with
@@ -772,7 +840,7 @@ func (r *configResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 	}
 
 	// retrieve plan
-	var plan configCommonModel
+	var plan configResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -791,14 +859,127 @@ func (r *configResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 
 	// set updated SafeSearch attribute for plan
 	modifiedSafeSearch, diags := types.ObjectValueFrom(ctx, safeSearchModel{}.attrTypes(), &safeSearchServices)
-	diags.Append(diags...)
-	if diags.HasError() {
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// update only the SafeSearch attribute in the plan
 	plan.SafeSearch = modifiedSafeSearch
 
+	// with partial ownership, sections not present in the configuration are not managed
+	if plan.PartialOwnership.ValueBool() {
+		var config configResourceModel
+		diags = req.Config.Get(ctx, &config)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if config.Filtering.IsNull() {
+			plan.Filtering = types.ObjectNull(filteringModel{}.attrTypes())
+		}
+		if config.SafeBrowsing.IsNull() {
+			plan.SafeBrowsing = types.BoolNull()
+		}
+		if config.ParentalControl.IsNull() {
+			plan.ParentalControl = types.BoolNull()
+		}
+		if config.SafeSearch.IsNull() {
+			plan.SafeSearch = types.ObjectNull(safeSearchModel{}.attrTypes())
+		}
+		if config.QueryLog.IsNull() {
+			plan.QueryLog = types.ObjectNull(queryLogConfigModel{}.attrTypes())
+		}
+		if config.Stats.IsNull() {
+			plan.Stats = types.ObjectNull(statsConfigModel{}.attrTypes())
+		}
+		// blocked services and their pause schedule are managed together
+		if config.BlockedServices.IsNull() && config.BlockedServicesPauseSchedule.IsNull() {
+			plan.BlockedServices = types.SetNull(types.StringType)
+			plan.BlockedServicesPauseSchedule = types.ObjectNull(scheduleModel{}.attrTypes())
+		}
+		if config.Dns.IsNull() {
+			plan.Dns = types.ObjectNull(dnsConfigModel{}.attrTypes())
+		}
+		if config.Dhcp.IsNull() {
+			plan.Dhcp = types.ObjectNull(dhcpConfigModel{}.attrTypes())
+		}
+		if config.Tls.IsNull() {
+			plan.Tls = types.ObjectNull(tlsConfigModel{}.attrTypes())
+		}
+		if config.Rewrites.IsNull() {
+			plan.Rewrites = types.BoolNull()
+		}
+	}
+
+	// DNS
+	// the access list defaults do not apply when it is managed by other resources
+	if !plan.Dns.IsNull() && !plan.Dns.IsUnknown() && !isAccessListManaged(plan.Dns) {
+		var planDnsConfig dnsConfigModel
+		diags = plan.Dns.As(ctx, &planDnsConfig, basetypes.ObjectAsOptions{})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		planDnsConfig.AllowedClients = types.SetNull(clientIdType)
+		planDnsConfig.DisallowedClients = types.SetNull(clientIdType)
+		planDnsConfig.BlockedHosts = types.SetNull(domainType{})
+		plan.Dns, diags = types.ObjectValueFrom(ctx, dnsConfigModel{}.attrTypes(), &planDnsConfig)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// TLS
+	// validate the certificate chain and private key in the plan
+	if !plan.Tls.IsNull() && !plan.Tls.IsUnknown() {
+		var planTlsConfig tlsConfigModel
+		diags = plan.Tls.As(ctx, &planTlsConfig, basetypes.ObjectAsOptions{})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// values only known after apply cannot be validated
+		if !planTlsConfig.CertificateChain.IsUnknown() && !planTlsConfig.PrivateKey.IsUnknown() && !planTlsConfig.ServerName.IsUnknown() {
+			var tlsConfig adgmodels.TlsConfig
+			tlsConfig.Enabled = planTlsConfig.Enabled.ValueBool()
+			tlsConfig.ServerName = planTlsConfig.ServerName.ValueString()
+			setTlsCertificateAndKey(&tlsConfig, planTlsConfig.CertificateChain.ValueString(), planTlsConfig.PrivateKey.ValueString())
+
+			// only ask AdGuard Home to validate when the certificate, key or server name change
+			validateWithServer := planTlsConfig.ValidateWithServer.ValueBool()
+			if validateWithServer && !req.State.Raw.IsNull() {
+				var stateTls types.Object
+				diags = req.State.GetAttribute(ctx, path.Root("tls"), &stateTls)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				if !stateTls.IsNull() {
+					var stateTlsConfig tlsConfigModel
+					diags = stateTls.As(ctx, &stateTlsConfig, basetypes.ObjectAsOptions{})
+					resp.Diagnostics.Append(diags...)
+					if resp.Diagnostics.HasError() {
+						return
+					}
+					validateWithServer = !planTlsConfig.Enabled.Equal(stateTlsConfig.Enabled) ||
+						!planTlsConfig.ServerName.Equal(stateTlsConfig.ServerName) ||
+						!planTlsConfig.CertificateChain.Equal(stateTlsConfig.CertificateChain) ||
+						!planTlsConfig.PrivateKey.Equal(stateTlsConfig.PrivateKey)
+				}
+			}
+
+			validateTlsConfig(r.adg, tlsConfig, planTlsConfig.ExpiryWarningDays.ValueInt64(), validateWithServer,
+				path.Root("tls").AtName("certificate_chain"), path.Root("tls").AtName("private_key"), &resp.Diagnostics)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
 	// set the modified plan
 	diags = resp.Plan.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -819,18 +1000,42 @@ func (r *configResource) Configure(_ context.Context, req resource.ConfigureRequ
 // Create creates the resource and sets the initial Terraform state
 func (r *configResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// retrieve values from plan
-	var plan configCommonModel
+	var plan configResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// capture the current server configuration so it can be restored on destroy
+	var snapshot configSnapshot
+	err := snapshot.capture(r, &plan.configCommonModel)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating AdGuard Home Config",
+			"Could not capture current config, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	snapshotJson, err := json.Marshal(snapshot)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating AdGuard Home Config",
+			"Could not serialize current config, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	diags = resp.Private.SetKey(ctx, snapshotPrivateStateKey, snapshotJson)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// empty state as it's a create operation
 	var state configCommonModel
 
 	// defer to common function to create or update the resource
-	r.CreateOrUpdate(ctx, &plan, &state, &resp.Diagnostics)
+	r.CreateOrUpdate(ctx, &plan.configCommonModel, &state, &resp.Diagnostics)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
@@ -852,17 +1057,17 @@ func (r *configResource) Create(ctx context.Context, req resource.CreateRequest,
 // Read refreshes the Terraform state with the latest data
 func (r *configResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// get current state
-	var state configCommonModel
+	var state configResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// use common model for state
-	var newState configCommonModel
+	// use resource model for state
+	var newState configResourceModel
 	// use common Read function
-	newState.Read(ctx, *r.adg, &state, &resp.Diagnostics, "resource")
+	newState.Read(ctx, *r.adg, &state.configCommonModel, &resp.Diagnostics, "resource", state.PartialOwnership.ValueBool())
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -870,6 +1075,18 @@ func (r *configResource) Read(ctx context.Context, req resource.ReadRequest, res
 	// populate internal fields into new state
 	newState.ID = state.ID
 	newState.LastUpdated = state.LastUpdated
+	// partial ownership is null after an import, in which case all sections are managed
+	if state.PartialOwnership.IsNull() {
+		newState.PartialOwnership = types.BoolValue(CONFIG_PARTIAL_OWNERSHIP)
+	} else {
+		newState.PartialOwnership = state.PartialOwnership
+	}
+	// same for the destroy behavior
+	if state.OnDestroy.IsNull() {
+		newState.OnDestroy = types.StringValue(ON_DESTROY)
+	} else {
+		newState.OnDestroy = state.OnDestroy
+	}
 
 	// set refreshed state
 	diags = resp.State.Set(ctx, &newState)
@@ -882,7 +1099,7 @@ func (r *configResource) Read(ctx context.Context, req resource.ReadRequest, res
 // Update updates the resource and sets the updated Terraform state on success
 func (r *configResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// retrieve values from plan
-	var plan configCommonModel
+	var plan configResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -890,15 +1107,45 @@ func (r *configResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// retrieve values from state
-	var state configCommonModel
+	var state configResourceModel
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// capture the current server configuration of sections newly owned by the resource,
+	// unless there is no snapshot to begin with (e.g. after an import)
+	snapshotJson, diags := req.Private.GetKey(ctx, snapshotPrivateStateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if snapshotJson != nil {
+		var snapshot configSnapshot
+		err := json.Unmarshal(snapshotJson, &snapshot)
+		if err == nil {
+			err = snapshot.capture(r, &plan.configCommonModel)
+		}
+		if err == nil {
+			snapshotJson, err = json.Marshal(snapshot)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating AdGuard Home Config",
+				"Could not capture current config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		diags = resp.Private.SetKey(ctx, snapshotPrivateStateKey, snapshotJson)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// defer to common function to create or update the resource
-	r.CreateOrUpdate(ctx, &plan, &state, &resp.Diagnostics)
+	r.CreateOrUpdate(ctx, &plan.configCommonModel, &state.configCommonModel, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -918,249 +1165,333 @@ func (r *configResource) Update(ctx context.Context, req resource.UpdateRequest,
 func (r *configResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// there is no "real" delete for the configuration, so this means "restore defaults"
 
-	// populate filtering config with default values
-	var filterConfig adgmodels.FilterConfig
-	filterConfig.Enabled = CONFIG_FILTERING_ENABLED
-	filterConfig.Interval = CONFIG_FILTERING_UPDATE_INTERVAL
-
-	// set filtering config to default
-	err := r.adg.FilteringConfig(filterConfig)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
+	// retrieve values from state
+	var state configResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// set safebrowsing to default
-	if CONFIG_SAFEBROWSING_ENABLED {
-		err = r.adg.SafeBrowsingEnable()
-	} else {
-		err = r.adg.SafeBrowsingDisable()
-	}
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
+	var err error
+
+	switch state.OnDestroy.ValueString() {
+	case "retain":
+		// leave the server configuration as is
+		return
+	case "restore_previous":
+		// retrieve the server configuration captured at creation
+		snapshotJson, diags := req.Private.GetKey(ctx, snapshotPrivateStateKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if snapshotJson == nil {
+			resp.Diagnostics.AddWarning(
+				"No Previous AdGuard Home Config to Restore",
+				"The config was not created by Terraform (e.g. it was imported), so the current config is retained",
+			)
+			return
+		}
+		var snapshot configSnapshot
+		err = json.Unmarshal(snapshotJson, &snapshot)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home Config",
+				"Could not parse previous config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		// restore the sections owned by this resource
+		snapshot.restore(ctx, r, &state.configCommonModel, &resp.Diagnostics)
 		return
 	}
 
-	// set parental to default
-	if CONFIG_PARENTAL_CONTROL_ENABLED {
-		err = r.adg.ParentalEnable()
-	} else {
-		err = r.adg.ParentalDisable()
+	// only reset the sections owned by this resource, which are all of them unless using partial ownership
+	if !state.Filtering.IsNull() {
+		// populate filtering config with default values
+		var filterConfig adgmodels.FilterConfig
+		filterConfig.Enabled = CONFIG_FILTERING_ENABLED
+		filterConfig.Interval = CONFIG_FILTERING_UPDATE_INTERVAL
+
+		// set filtering config to default
+		err = r.adg.FilteringConfig(filterConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home Config",
+				"Could not delete config, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
-		return
+
+	if !state.SafeBrowsing.IsNull() {
+		// set safebrowsing to default
+		if CONFIG_SAFEBROWSING_ENABLED {
+			err = r.adg.SafeBrowsingEnable()
+		} else {
+			err = r.adg.SafeBrowsingDisable()
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home Config",
+				"Could not delete config, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 
-	// populate safe search with default values
-	var safeSearchConfig adgmodels.SafeSearchConfig
-	safeSearchConfig.Enabled = SAFE_SEARCH_ENABLED
-	safeSearchConfig.Bing = true
-	safeSearchConfig.Duckduckgo = true
-	safeSearchConfig.Google = true
-	safeSearchConfig.Pixabay = true
-	safeSearchConfig.Yandex = true
-	safeSearchConfig.Youtube = true
-
-	// set safe search to defaults
-	err = r.adg.SafeSearchSettings(safeSearchConfig)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
-		return
+	if !state.ParentalControl.IsNull() {
+		// set parental to default
+		if CONFIG_PARENTAL_CONTROL_ENABLED {
+			err = r.adg.ParentalEnable()
+		} else {
+			err = r.adg.ParentalDisable()
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home Config",
+				"Could not delete config, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 
-	// populate query log config with default values
-	var queryLogConfig adgmodels.GetQueryLogConfigResponse
-	queryLogConfig.Enabled = CONFIG_QUERYLOG_ENABLED
-	queryLogConfig.Interval = CONFIG_QUERYLOG_INTERVAL * 3600 * 1000
-	queryLogConfig.AnonymizeClientIp = CONFIG_QUERYLOG_ANONYMIZE_CLIENT_IP
-	queryLogConfig.Ignored = []string{}
+	if !state.SafeSearch.IsNull() {
+		// populate safe search with default values
+		var safeSearchConfig adgmodels.SafeSearchConfig
+		safeSearchConfig.Enabled = SAFE_SEARCH_ENABLED
+		safeSearchConfig.Bing = true
+		safeSearchConfig.Duckduckgo = true
+		safeSearchConfig.Google = true
+		safeSearchConfig.Pixabay = true
+		safeSearchConfig.Yandex = true
+		safeSearchConfig.Youtube = true
 
-	// set query log config to defaults
-	err = r.adg.QuerylogConfigUpdate(queryLogConfig)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
-		return
+		// set safe search to defaults
+		err = r.adg.SafeSearchSettings(safeSearchConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home Config",
+				"Could not delete config, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 
-	// populate server statistics config with default values
-	var statsConfig adgmodels.GetStatsConfigResponse
-	statsConfig.Enabled = CONFIG_STATS_ENABLED
-	statsConfig.Interval = CONFIG_STATS_INTERVAL * 3600 * 1000
-	statsConfig.Ignored = []string{}
+	if !state.QueryLog.IsNull() {
+		// populate query log config with default values
+		var queryLogConfig adgmodels.GetQueryLogConfigResponse
+		queryLogConfig.Enabled = CONFIG_QUERYLOG_ENABLED
+		queryLogConfig.Interval = CONFIG_QUERYLOG_INTERVAL * 3600 * 1000
+		queryLogConfig.AnonymizeClientIp = CONFIG_QUERYLOG_ANONYMIZE_CLIENT_IP
+		queryLogConfig.Ignored = []string{}
 
-	// set server statistics to defaults
-	err = r.adg.StatsConfigUpdate(statsConfig)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
-		return
+		// set query log config to defaults
+		err = r.adg.QuerylogConfigUpdate(queryLogConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home Config",
+				"Could not delete config, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 
-	// populate blocked services and schedules with default values
-	var blockedServicesPauseScheduleConfig adgmodels.BlockedServicesSchedule
-	blockedServicesPauseScheduleConfig.Ids = make([]string, 0)
-	blockedServicesPauseScheduleConfig.Schedule.TimeZone = BLOCKED_SERVICES_PAUSE_SCHEDULE_TIMEZONE
-	blockedServicesPauseScheduleConfig.Schedule.Sunday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Sunday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Monday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Monday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Tuesday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Tuesday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Wednesday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Wednesday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Thursday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Thursday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Friday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Friday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Saturday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-	blockedServicesPauseScheduleConfig.Schedule.Saturday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
-
-	// set blocked services to defaults
-	err = r.adg.BlockedServicesUpdate(blockedServicesPauseScheduleConfig)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
-		return
+	if !state.Stats.IsNull() {
+		// populate server statistics config with default values
+		var statsConfig adgmodels.GetStatsConfigResponse
+		statsConfig.Enabled = CONFIG_STATS_ENABLED
+		statsConfig.Interval = CONFIG_STATS_INTERVAL * 3600 * 1000
+		statsConfig.Ignored = []string{}
+
+		// set server statistics to defaults
+		err = r.adg.StatsConfigUpdate(statsConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home Config",
+				"Could not delete config, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 
-	// instantiate empty DNS config for storing default values
-	var dnsConfig adgmodels.DNSConfig
-
-	// populate DNS config with default values
-	dnsConfig.BootstrapDns = CONFIG_DNS_BOOTSTRAP
-	dnsConfig.UpstreamDns = CONFIG_DNS_UPSTREAM
-	dnsConfig.FallbackDns = []string{}
-	dnsConfig.ProtectionEnabled = CONFIG_DNS_PROTECTION_ENABLED
-	dnsConfig.UpstreamDnsFile = ""
-	dnsConfig.RateLimit = CONFIG_DNS_RATE_LIMIT
-	dnsConfig.RateLimitSubnetSubnetLenIpv4 = CONFIG_DNS_RATE_LIMIT_SUBNET_LEN_IPV4
-	dnsConfig.RateLimitSubnetSubnetLenIpv6 = CONFIG_DNS_RATE_LIMIT_SUBNET_LEN_IPV6
-	dnsConfig.RateLimitWhitelist = []string{}
-	dnsConfig.BlockingMode = CONFIG_DNS_BLOCKING_MODE
-	dnsConfig.BlockingIpv4 = ""
-	dnsConfig.BlockingIpv6 = ""
-	dnsConfig.BlockedResponseTtl = CONFIG_DNS_BLOCKED_RESPONSE_TTL
-	dnsConfig.EDnsCsEnabled = CONFIG_DNS_EDNS_CS_ENABLED
-	dnsConfig.EDnsCsUseCustom = CONFIG_DNS_EDNS_CS_USE_CUSTOM
-	dnsConfig.EDnsCsCustomIp = ""
-	dnsConfig.DisableIpv6 = CONFIG_DNS_DISABLE_IPV6
-	dnsConfig.DnsSecEnabled = CONFIG_DNS_DNSSEC_ENABLED
-	dnsConfig.CacheEnabled = CONFIG_DNS_CACHE_ENABLED
-	dnsConfig.CacheSize = CONFIG_DNS_CACHE_SIZE
-	dnsConfig.CacheTtlMin = CONFIG_DNS_CACHE_TTL_MIN
-	dnsConfig.CacheTtlMax = CONFIG_DNS_CACHE_TTL_MAX
-	dnsConfig.CacheOptimistic = CONFIG_DNS_CACHE_OPTIMISTIC
-	dnsConfig.UpstreamMode = ""
-	dnsConfig.UsePrivatePtrResolvers = CONFIG_DNS_USE_PRIVATE_PTR_RESOLVERS
-	dnsConfig.ResolveClients = CONFIG_DNS_RESOLVE_CLIENTS
-	dnsConfig.LocalPtrUpstreams = []string{}
-	dnsConfig.UpstreamTimeout = CONFIG_DNS_UPSTREAM_TIMEOUT
-
-	// set dns config to defaults
-	err = r.adg.DnsConfig(dnsConfig)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
-		return
+	if !state.BlockedServicesPauseSchedule.IsNull() {
+		// populate blocked services and schedules with default values
+		var blockedServicesPauseScheduleConfig adgmodels.BlockedServicesSchedule
+		blockedServicesPauseScheduleConfig.Ids = make([]string, 0)
+		blockedServicesPauseScheduleConfig.Schedule.TimeZone = BLOCKED_SERVICES_PAUSE_SCHEDULE_TIMEZONE
+		blockedServicesPauseScheduleConfig.Schedule.Sunday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Sunday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Monday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Monday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Tuesday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Tuesday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Wednesday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Wednesday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Thursday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Thursday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Friday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Friday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Saturday.Start = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+		blockedServicesPauseScheduleConfig.Schedule.Saturday.End = BLOCKED_SERVICES_PAUSE_SCHEDULE_START_END
+
+		// set blocked services to defaults
+		err = r.adg.BlockedServicesUpdate(blockedServicesPauseScheduleConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home Config",
+				"Could not delete config, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 
-	// instantiate empty dns access list for storing default values
-	var dnsAccess adgmodels.AccessList
+	if !state.Dns.IsNull() {
+		// instantiate empty DNS config for storing default values
+		var dnsConfig adgmodels.DNSConfig
 
-	// populate dns access list with default values
-	dnsAccess.AllowedClients = []string{}
-	dnsAccess.DisallowedClients = []string{}
-	dnsAccess.BlockedHosts = CONFIG_DNS_BLOCKED_HOSTS
+		// populate DNS config with default values
+		dnsConfig.BootstrapDns = CONFIG_DNS_BOOTSTRAP
+		dnsConfig.UpstreamDns = CONFIG_DNS_UPSTREAM
+		dnsConfig.FallbackDns = []string{}
+		dnsConfig.ProtectionEnabled = CONFIG_DNS_PROTECTION_ENABLED
+		dnsConfig.UpstreamDnsFile = ""
+		dnsConfig.RateLimit = CONFIG_DNS_RATE_LIMIT
+		dnsConfig.RateLimitSubnetSubnetLenIpv4 = CONFIG_DNS_RATE_LIMIT_SUBNET_LEN_IPV4
+		dnsConfig.RateLimitSubnetSubnetLenIpv6 = CONFIG_DNS_RATE_LIMIT_SUBNET_LEN_IPV6
+		dnsConfig.RateLimitWhitelist = []string{}
+		dnsConfig.BlockingMode = CONFIG_DNS_BLOCKING_MODE
+		dnsConfig.BlockingIpv4 = ""
+		dnsConfig.BlockingIpv6 = ""
+		dnsConfig.BlockedResponseTtl = CONFIG_DNS_BLOCKED_RESPONSE_TTL
+		dnsConfig.EDnsCsEnabled = CONFIG_DNS_EDNS_CS_ENABLED
+		dnsConfig.EDnsCsUseCustom = CONFIG_DNS_EDNS_CS_USE_CUSTOM
+		dnsConfig.EDnsCsCustomIp = ""
+		dnsConfig.DisableIpv6 = CONFIG_DNS_DISABLE_IPV6
+		dnsConfig.DnsSecEnabled = CONFIG_DNS_DNSSEC_ENABLED
+		dnsConfig.CacheEnabled = CONFIG_DNS_CACHE_ENABLED
+		dnsConfig.CacheSize = CONFIG_DNS_CACHE_SIZE
+		dnsConfig.CacheTtlMin = CONFIG_DNS_CACHE_TTL_MIN
+		dnsConfig.CacheTtlMax = CONFIG_DNS_CACHE_TTL_MAX
+		dnsConfig.CacheOptimistic = CONFIG_DNS_CACHE_OPTIMISTIC
+		dnsConfig.UpstreamMode = ""
+		dnsConfig.UsePrivatePtrResolvers = CONFIG_DNS_USE_PRIVATE_PTR_RESOLVERS
+		dnsConfig.ResolveClients = CONFIG_DNS_RESOLVE_CLIENTS
+		dnsConfig.LocalPtrUpstreams = []string{}
+		dnsConfig.UpstreamTimeout = CONFIG_DNS_UPSTREAM_TIMEOUT
 
-	// set dns access list to defaults
-	err = r.adg.AccessSet(dnsAccess)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
-		return
-	}
+		// set dns config to defaults
+		err = r.adg.DnsConfig(dnsConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home Config",
+				"Could not delete config, unexpected error: "+err.Error(),
+			)
+			return
+		}
 
-	// set dhcp config to defaults
-	err = r.adg.DhcpReset()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
-		return
+		// check whether the access list is managed by this resource
+		var stateDnsConfig dnsConfigModel
+		diags = state.Dns.As(ctx, &stateDnsConfig, basetypes.ObjectAsOptions{})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if stateDnsConfig.ManageAccessList.IsNull() || stateDnsConfig.ManageAccessList.ValueBool() {
+			// instantiate empty dns access list for storing default values
+			var dnsAccess adgmodels.AccessList
+
+			// populate dns access list with default values
+			dnsAccess.AllowedClients = []string{}
+			dnsAccess.DisallowedClients = []string{}
+			dnsAccess.BlockedHosts = CONFIG_DNS_BLOCKED_HOSTS
+
+			// set dns access list to defaults
+			err = r.adg.AccessSet(dnsAccess)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Deleting AdGuard Home Config",
+					"Could not delete config, unexpected error: "+err.Error(),
+				)
+				return
+			}
+		}
 	}
 
-	// remove all dhcp static leases
-	err = r.adg.DhcpResetLeases()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
-		return
+	if !state.Dhcp.IsNull() {
+		// set dhcp config to defaults
+		err = r.adg.DhcpReset()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home Config",
+				"Could not delete config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		// check whether static leases are managed by this resource
+		var stateDhcpConfig dhcpConfigModel
+		diags = state.Dhcp.As(ctx, &stateDhcpConfig, basetypes.ObjectAsOptions{})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if stateDhcpConfig.ManageStaticLeases.IsNull() || stateDhcpConfig.ManageStaticLeases.ValueBool() {
+			// remove all dhcp static leases
+			err = r.adg.DhcpResetLeases()
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Deleting AdGuard Home Config",
+					"Could not delete config, unexpected error: "+err.Error(),
+				)
+				return
+			}
+		}
 	}
 
-	// instantiate empty tls config for storing default values
-	var tlsConfig adgmodels.TlsConfig
-
-	// populate tls config list with default values
-	tlsConfig.Enabled = CONFIG_TLS_ENABLED
-	tlsConfig.ServerName = ""
-	tlsConfig.ForceHttps = CONFIG_TLS_FORCE_HTTPS
-	tlsConfig.PortHttps = CONFIG_TLS_PORT_HTTPS
-	tlsConfig.PortDnsOverTls = CONFIG_TLS_PORT_DNS_OVER_TLS
-	tlsConfig.PortDnsOverQuic = CONFIG_TLS_PORT_DNS_OVER_QUIC
-	tlsConfig.CertificateChain = ""
-	tlsConfig.PrivateKey = ""
-	tlsConfig.CertificatePath = ""
-	tlsConfig.PrivateKeyPath = ""
-	// plain DNS is required in case encryption protocols are disabled
-	tlsConfig.ServePlainDns = true
-
-	// set tls config to defaults
-	_, err = r.adg.TlsConfigure(tlsConfig)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
-		return
+	if !state.Tls.IsNull() {
+		// instantiate empty tls config for storing default values
+		var tlsConfig adgmodels.TlsConfig
+
+		// populate tls config list with default values
+		tlsConfig.Enabled = CONFIG_TLS_ENABLED
+		tlsConfig.ServerName = ""
+		tlsConfig.ForceHttps = CONFIG_TLS_FORCE_HTTPS
+		tlsConfig.PortHttps = CONFIG_TLS_PORT_HTTPS
+		tlsConfig.PortDnsOverTls = CONFIG_TLS_PORT_DNS_OVER_TLS
+		tlsConfig.PortDnsOverQuic = CONFIG_TLS_PORT_DNS_OVER_QUIC
+		tlsConfig.CertificateChain = ""
+		tlsConfig.PrivateKey = ""
+		tlsConfig.CertificatePath = ""
+		tlsConfig.PrivateKeyPath = ""
+		// plain DNS is required in case encryption protocols are disabled
+		tlsConfig.ServePlainDns = true
+
+		// set tls config to defaults
+		_, err = r.adg.TlsConfigure(tlsConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home Config",
+				"Could not delete config, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 
-	// set rewrites to default
-	var rewriteSettings adgmodels.RewriteSettings
-	rewriteSettings.Enabled = CONFIG_REWRITES_ENABLED
-	err = r.adg.RewriteSettingsUpdate(rewriteSettings)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting AdGuard Home Config",
-			"Could not delete config, unexpected error: "+err.Error(),
-		)
-		return
+	if !state.Rewrites.IsNull() {
+		// set rewrites to default
+		var rewriteSettings adgmodels.RewriteSettings
+		rewriteSettings.Enabled = CONFIG_REWRITES_ENABLED
+		err = r.adg.RewriteSettingsUpdate(rewriteSettings)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home Config",
+				"Could not delete config, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 }
 
@@ -1,6 +1,7 @@
 package adguard
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -10,6 +11,35 @@ func TestAccConfigResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
+			// Invalid DHCP config testing
+			{
+				Config: providerConfig + `
+resource "adguard_config" "test" {
+	dhcp = {
+		interface = "eth1"
+		ipv4_settings = {
+			gateway_ip  = "192.168.250.1"
+			subnet_mask = "255.255.255.0"
+			range_start = "192.168.250.100"
+			range_end   = "192.168.251.10"
+		}
+		static_leases = [
+			{
+				mac      = "00:11:22:33:44:55"
+				ip       = "192.168.250.20"
+				hostname = "test-lease-1"
+			},
+			{
+				mac      = "00:11:22:33:44:55"
+				ip       = "192.168.250.30"
+				hostname = "test-lease-2"
+			}
+		]
+	}
+}
+`,
+				ExpectError: regexp.MustCompile("DHCP Config Invalid"),
+			},
 			// Create and Read testing
 			{
 				Config: providerConfig + `
@@ -154,6 +184,8 @@ resource "adguard_config" "test" {
 					resource.TestCheckResourceAttr("adguard_config.test", "tls.server_name", "Test AdGuard Home"),
 					resource.TestCheckResourceAttr("adguard_config.test", "tls.issuer", "CN=TestRootCA,O=AdGuard Home,L=Dallas,ST=Texas,C=US"),
 					resource.TestCheckResourceAttr("adguard_config.test", "tls.serve_plain_dns", "false"),
+					resource.TestCheckResourceAttr("adguard_config.test", "tls.expiry_warning_days", "30"),
+					resource.TestCheckResourceAttr("adguard_config.test", "tls.validate_with_server", "false"),
 					resource.TestCheckResourceAttr("adguard_config.test", "rewrites", "true"),
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("adguard_config.test", "id"),
@@ -317,6 +349,36 @@ resource "adguard_config" "test" {
 					resource.TestCheckResourceAttr("adguard_config.test", "rewrites", "false"),
 				),
 			},
+			// Update with partial ownership testing
+			{
+				Config: providerConfig + `
+resource "adguard_config" "test" {
+  partial_ownership = true
+  on_destroy        = "restore_previous"
+
+  dns = {
+    upstream_dns = ["https://1.1.1.1/dns-query"]
+    rate_limit   = 30
+  }
+  rewrites = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_config.test", "partial_ownership", "true"),
+					resource.TestCheckResourceAttr("adguard_config.test", "on_destroy", "restore_previous"),
+					resource.TestCheckResourceAttr("adguard_config.test", "dns.upstream_dns.#", "1"),
+					resource.TestCheckResourceAttr("adguard_config.test", "dns.upstream_dns.0", "https://1.1.1.1/dns-query"),
+					resource.TestCheckResourceAttr("adguard_config.test", "dns.rate_limit", "30"),
+					resource.TestCheckResourceAttr("adguard_config.test", "rewrites", "false"),
+					resource.TestCheckNoResourceAttr("adguard_config.test", "filtering.enabled"),
+					resource.TestCheckNoResourceAttr("adguard_config.test", "safebrowsing"),
+					resource.TestCheckNoResourceAttr("adguard_config.test", "querylog.enabled"),
+					resource.TestCheckNoResourceAttr("adguard_config.test", "stats.enabled"),
+					resource.TestCheckNoResourceAttr("adguard_config.test", "blocked_services_pause_schedule.time_zone"),
+					resource.TestCheckNoResourceAttr("adguard_config.test", "dhcp.interface"),
+					resource.TestCheckNoResourceAttr("adguard_config.test", "tls.enabled"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
@@ -0,0 +1,339 @@
+package adguard
+
+import (
+	"context"
+
+	adgmodels "github.com/gmichels/adguard-client-go/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// configSnapshot holds the server configuration as it was before being managed by Terraform,
+// with one entry per section so that only owned sections are captured and restored
+type configSnapshot struct {
+	Filtering       *adgmodels.FilterConfig              `json:"filtering,omitempty"`
+	SafeBrowsing    *bool                                `json:"safebrowsing,omitempty"`
+	ParentalControl *bool                                `json:"parental_control,omitempty"`
+	SafeSearch      *adgmodels.SafeSearchConfig          `json:"safesearch,omitempty"`
+	QueryLog        *adgmodels.GetQueryLogConfigResponse `json:"querylog,omitempty"`
+	Stats           *adgmodels.GetStatsConfigResponse    `json:"stats,omitempty"`
+	BlockedServices *adgmodels.BlockedServicesSchedule   `json:"blocked_services,omitempty"`
+	Dns             *adgmodels.DNSConfig                 `json:"dns,omitempty"`
+	DnsAccess       *adgmodels.AccessList                `json:"dns_access,omitempty"`
+	Dhcp            *adgmodels.DhcpStatus                `json:"dhcp,omitempty"`
+	Tls             *adgmodels.TlsConfig                 `json:"tls,omitempty"`
+	Rewrites        *adgmodels.RewriteSettings           `json:"rewrites,omitempty"`
+}
+
+// capture - fetches the current server configuration for the sections in the plan not yet in the snapshot
+func (s *configSnapshot) capture(r *configResource, plan *configCommonModel) error {
+	if !plan.Filtering.IsNull() && s.Filtering == nil {
+		filteringStatus, err := r.adg.FilteringStatus()
+		if err != nil {
+			return err
+		}
+		s.Filtering = &adgmodels.FilterConfig{Enabled: filteringStatus.Enabled, Interval: filteringStatus.Interval}
+	}
+
+	if !plan.SafeBrowsing.IsNull() && s.SafeBrowsing == nil {
+		safeBrowsingStatus, err := r.adg.SafeBrowsingStatus()
+		if err != nil {
+			return err
+		}
+		s.SafeBrowsing = &safeBrowsingStatus.Enabled
+	}
+
+	if !plan.ParentalControl.IsNull() && s.ParentalControl == nil {
+		parentalStatus, err := r.adg.ParentalStatus()
+		if err != nil {
+			return err
+		}
+		s.ParentalControl = &parentalStatus.Enabled
+	}
+
+	if !plan.SafeSearch.IsNull() && s.SafeSearch == nil {
+		safeSearchConfig, err := r.adg.SafeSearchStatus()
+		if err != nil {
+			return err
+		}
+		s.SafeSearch = safeSearchConfig
+	}
+
+	if !plan.QueryLog.IsNull() && s.QueryLog == nil {
+		queryLogConfig, err := r.adg.QuerylogConfig()
+		if err != nil {
+			return err
+		}
+		s.QueryLog = queryLogConfig
+	}
+
+	if !plan.Stats.IsNull() && s.Stats == nil {
+		statsConfig, err := r.adg.StatsConfig()
+		if err != nil {
+			return err
+		}
+		s.Stats = statsConfig
+	}
+
+	if !plan.BlockedServicesPauseSchedule.IsNull() && s.BlockedServices == nil {
+		blockedServicesPauseSchedule, err := r.adg.BlockedServicesGet()
+		if err != nil {
+			return err
+		}
+		s.BlockedServices = blockedServicesPauseSchedule
+	}
+
+	if !plan.Dns.IsNull() && s.Dns == nil {
+		dnsConfig, err := r.adg.DnsInfo()
+		if err != nil {
+			return err
+		}
+		s.Dns = dnsConfig
+		// the access list is left alone when managed by other resources
+		if isAccessListManaged(plan.Dns) {
+			dnsAccess, err := r.adg.AccessList()
+			if err != nil {
+				return err
+			}
+			s.DnsAccess = dnsAccess
+		}
+	}
+
+	if !plan.Dhcp.IsNull() && s.Dhcp == nil {
+		dhcpStatus, err := r.adg.DhcpStatus()
+		if err != nil {
+			return err
+		}
+		// dynamic leases are not restored
+		dhcpStatus.Leases = nil
+		s.Dhcp = dhcpStatus
+	}
+
+	if !plan.Tls.IsNull() && s.Tls == nil {
+		tlsConfig, err := r.adg.TlsStatus()
+		if err != nil {
+			return err
+		}
+		s.Tls = tlsConfig
+	}
+
+	if !plan.Rewrites.IsNull() && s.Rewrites == nil {
+		rewriteSettings, err := r.adg.RewriteSettings()
+		if err != nil {
+			return err
+		}
+		s.Rewrites = rewriteSettings
+	}
+
+	return nil
+}
+
+// restore - writes back the captured server configuration for the sections owned by the resource
+func (s *configSnapshot) restore(ctx context.Context, r *configResource, state *configCommonModel, diags *diag.Diagnostics) {
+	var err error
+
+	if !state.Filtering.IsNull() && s.Filtering != nil {
+		err = r.adg.FilteringConfig(*s.Filtering)
+		if err != nil {
+			diags.AddError(
+				"Error Restoring AdGuard Home Config",
+				"Could not restore filtering config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !state.SafeBrowsing.IsNull() && s.SafeBrowsing != nil {
+		if *s.SafeBrowsing {
+			err = r.adg.SafeBrowsingEnable()
+		} else {
+			err = r.adg.SafeBrowsingDisable()
+		}
+		if err != nil {
+			diags.AddError(
+				"Error Restoring AdGuard Home Config",
+				"Could not restore safe browsing config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !state.ParentalControl.IsNull() && s.ParentalControl != nil {
+		if *s.ParentalControl {
+			err = r.adg.ParentalEnable()
+		} else {
+			err = r.adg.ParentalDisable()
+		}
+		if err != nil {
+			diags.AddError(
+				"Error Restoring AdGuard Home Config",
+				"Could not restore parental control config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !state.SafeSearch.IsNull() && s.SafeSearch != nil {
+		err = r.adg.SafeSearchSettings(*s.SafeSearch)
+		if err != nil {
+			diags.AddError(
+				"Error Restoring AdGuard Home Config",
+				"Could not restore safe search config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !state.QueryLog.IsNull() && s.QueryLog != nil {
+		err = r.adg.QuerylogConfigUpdate(*s.QueryLog)
+		if err != nil {
+			diags.AddError(
+				"Error Restoring AdGuard Home Config",
+				"Could not restore query log config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !state.Stats.IsNull() && s.Stats != nil {
+		err = r.adg.StatsConfigUpdate(*s.Stats)
+		if err != nil {
+			diags.AddError(
+				"Error Restoring AdGuard Home Config",
+				"Could not restore server statistics config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !state.BlockedServicesPauseSchedule.IsNull() && s.BlockedServices != nil {
+		err = r.adg.BlockedServicesUpdate(*s.BlockedServices)
+		if err != nil {
+			diags.AddError(
+				"Error Restoring AdGuard Home Config",
+				"Could not restore blocked services config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !state.Dns.IsNull() && s.Dns != nil {
+		err = r.adg.DnsConfig(*s.Dns)
+		if err != nil {
+			diags.AddError(
+				"Error Restoring AdGuard Home Config",
+				"Could not restore DNS config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if s.DnsAccess != nil && isAccessListManaged(state.Dns) {
+			err = r.adg.AccessSet(*s.DnsAccess)
+			if err != nil {
+				diags.AddError(
+					"Error Restoring AdGuard Home Config",
+					"Could not restore DNS access list, unexpected error: "+err.Error(),
+				)
+				return
+			}
+		}
+	}
+
+	if !state.Dhcp.IsNull() && s.Dhcp != nil {
+		s.restoreDhcp(ctx, r, state, diags)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	if !state.Tls.IsNull() && s.Tls != nil {
+		tlsConfig := *s.Tls
+		// AdGuard Home never returns a saved private key, so an inline one cannot be restored
+		if tlsConfig.PrivateKeySaved && tlsConfig.PrivateKeyPath == "" {
+			diags.AddWarning(
+				"AdGuard Home TLS Private Key Not Restored",
+				"The private key saved before Terraform managed the TLS config cannot be retrieved from AdGuard Home, "+
+					"so the currently saved private key is kept. Supply the original private key again if required.",
+			)
+		}
+		_, err = r.adg.TlsConfigure(tlsConfig)
+		if err != nil {
+			diags.AddError(
+				"Error Restoring AdGuard Home Config",
+				"Could not restore TLS config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !state.Rewrites.IsNull() && s.Rewrites != nil {
+		err = r.adg.RewriteSettingsUpdate(*s.Rewrites)
+		if err != nil {
+			diags.AddError(
+				"Error Restoring AdGuard Home Config",
+				"Could not restore rewrites config, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+}
+
+// restoreDhcp - writes back the captured DHCP config and, when managed by the resource, its static leases
+func (s *configSnapshot) restoreDhcp(ctx context.Context, r *configResource, state *configCommonModel, diags *diag.Diagnostics) {
+	var err error
+
+	if s.Dhcp.InterfaceName == "" {
+		// the DHCP server was never configured
+		err = r.adg.DhcpReset()
+	} else {
+		err = r.adg.DhcpSetConfig(adgmodels.DhcpConfig{
+			Enabled:       s.Dhcp.Enabled,
+			InterfaceName: s.Dhcp.InterfaceName,
+			V4:            s.Dhcp.V4,
+			V6:            s.Dhcp.V6,
+		})
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Restoring AdGuard Home Config",
+			"Could not restore DHCP config, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// static leases are left alone when managed by other resources
+	var stateDhcpConfig dhcpConfigModel
+	d := state.Dhcp.As(ctx, &stateDhcpConfig, basetypes.ObjectAsOptions{})
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+	if !stateDhcpConfig.ManageStaticLeases.IsNull() && !stateDhcpConfig.ManageStaticLeases.ValueBool() {
+		return
+	}
+
+	err = r.adg.DhcpResetLeases()
+	if err != nil {
+		diags.AddError(
+			"Error Restoring AdGuard Home Config",
+			"Could not restore DHCP static leases, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	for _, staticLease := range s.Dhcp.StaticLeases {
+		err = r.adg.DhcpAddStaticLease(staticLease)
+		if err != nil {
+			diags.AddError(
+				"Error Restoring AdGuard Home Config",
+				"Could not restore DHCP static lease "+staticLease.Mac+", unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+}
+
+// isAccessListManaged - returns whether the DNS access list is managed by the config resource
+func isAccessListManaged(dns types.Object) bool {
+	manageAccessList, ok := dns.Attributes()["manage_access_list"].(types.Bool)
+	return !ok || manageAccessList.IsNull() || manageAccessList.IsUnknown() || manageAccessList.ValueBool()
+}
@@ -0,0 +1,27 @@
+package adguard
+
+import (
+	"strings"
+
+	"github.com/gmichels/adguard-client-go"
+	adgmodels "github.com/gmichels/adguard-client-go/models"
+)
+
+// GetDhcpStaticLease - Return a DHCP static lease based on its MAC address
+func GetDhcpStaticLease(adg *adguard.ADG, mac string) (*adgmodels.DhcpStaticLease, error) {
+	// retrieve DHCP status, which includes all static leases
+	dhcpStatus, err := adg.DhcpStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	// loop over the results until we find the one we want
+	for _, staticLease := range dhcpStatus.StaticLeases {
+		if normalizeNetworkAddress(networkAddressMac, strings.ToLower(staticLease.Mac)) == normalizeNetworkAddress(networkAddressMac, strings.ToLower(mac)) {
+			return &staticLease, nil
+		}
+	}
+
+	// when no matches are found
+	return nil, nil
+}
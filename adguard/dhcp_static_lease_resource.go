@@ -0,0 +1,317 @@
+package adguard
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/gmichels/adguard-client-go"
+	adgmodels "github.com/gmichels/adguard-client-go/models"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &dhcpStaticLeaseResource{}
+	_ resource.ResourceWithConfigure   = &dhcpStaticLeaseResource{}
+	_ resource.ResourceWithImportState = &dhcpStaticLeaseResource{}
+)
+
+// dhcpStaticLeaseResource is the resource implementation
+type dhcpStaticLeaseResource struct {
+	adg *adguard.ADG
+}
+
+// dhcpStaticLeaseResourceModel maps DHCP static lease schema data
+type dhcpStaticLeaseResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+	dhcpStaticLeasesModel
+}
+
+// NewDhcpStaticLeaseResource is a helper function to simplify the provider implementation
+func NewDhcpStaticLeaseResource() resource.Resource {
+	return &dhcpStaticLeaseResource{}
+}
+
+// Metadata returns the resource type name
+func (r *dhcpStaticLeaseResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dhcp_static_lease"
+}
+
+// Schema defines the schema for the resource
+func (r *dhcpStaticLeaseResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal identifier for this DHCP static lease",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the DHCP static lease",
+				Computed:    true,
+			},
+			"mac": schema.StringAttribute{
+				Description: "MAC address associated with the static lease",
+				CustomType:  macAddressType,
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					useStateForSemanticEquality(macAddressType),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^([0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}$`),
+						"must be a valid MAC address",
+					),
+				},
+			},
+			"ip": schema.StringAttribute{
+				Description: "IP address associated with the static lease",
+				CustomType:  ipAddressType,
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					useStateForSemanticEquality(ipAddressType),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^((25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$`),
+						"must be a valid IPv4 address",
+					),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Description: "Hostname associated with the static lease",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-z0-9-]+$`),
+						"must be a valid hostname",
+					),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *dhcpStaticLeaseResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.adg = req.ProviderData.(*adguard.ADG)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *dhcpStaticLeaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan dhcpStaticLeaseResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// instantiate empty DHCP static lease for storing plan data
+	var staticLease adgmodels.DhcpStaticLease
+
+	// populate DHCP static lease from plan
+	staticLease.Mac = plan.Mac.ValueString()
+	staticLease.Ip = plan.Ip.ValueString()
+	staticLease.Hostname = plan.Hostname.ValueString()
+
+	// create new DHCP static lease using plan
+	err := r.adg.DhcpAddStaticLease(staticLease)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating DHCP Static Lease",
+			"Could not create DHCP static lease, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// the MAC address uniquely identifies a static lease, so use its canonical spelling as ID
+	plan.ID = types.StringValue(normalizeNetworkAddress(networkAddressMac, staticLease.Mac))
+	// add the last updated attribute
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *dhcpStaticLeaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get current state
+	var state dhcpStaticLeaseResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get refreshed DHCP static lease value from AdGuard Home
+	staticLease, err := GetDhcpStaticLease(r.adg, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading AdGuard Home DHCP Static Lease",
+			"Could not read AdGuard Home DHCP static lease with ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	// convert to JSON for response logging
+	staticLeaseJson, err := json.Marshal(staticLease)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse AdGuard Home DHCP Static Lease",
+			err.Error(),
+		)
+		return
+	}
+	// log response body
+	tflog.Debug(ctx, "ADG API response", map[string]interface{}{
+		"object": "dhcpStaticLease",
+		"body":   string(staticLeaseJson),
+	})
+	if staticLease == nil {
+		resp.Diagnostics.AddWarning(
+			"AdGuard Home DHCP Static Lease was deleted outside of Terraform",
+			"No such DHCP static lease with id "+state.ID.ValueString(),
+		)
+		// remove from state
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// overwrite DHCP static lease with refreshed state
+	state.ID = types.StringValue(normalizeNetworkAddress(networkAddressMac, staticLease.Mac))
+	state.Mac = newNetworkAddressValue(macAddressType, staticLease.Mac)
+	state.Ip = newNetworkAddressValue(ipAddressType, staticLease.Ip)
+	state.Hostname = types.StringValue(staticLease.Hostname)
+
+	// set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *dhcpStaticLeaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan dhcpStaticLeaseResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve state as we need the current info
+	var state dhcpStaticLeaseResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// generate API request bodies from state and plan
+	var currentStaticLease adgmodels.DhcpStaticLease
+	currentStaticLease.Mac = state.Mac.ValueString()
+	currentStaticLease.Ip = state.Ip.ValueString()
+	currentStaticLease.Hostname = state.Hostname.ValueString()
+
+	var updatedStaticLease adgmodels.DhcpStaticLease
+	updatedStaticLease.Mac = plan.Mac.ValueString()
+	updatedStaticLease.Ip = plan.Ip.ValueString()
+	updatedStaticLease.Hostname = plan.Hostname.ValueString()
+
+	// there is no update operation for static leases, so remove the existing one first
+	err := r.adg.DhcpRemoveStaticLease(currentStaticLease)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating AdGuard Home DHCP Static Lease",
+			"Could not update DHCP static lease, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// and then add it back with the updated values
+	err = r.adg.DhcpAddStaticLease(updatedStaticLease)
+	if err != nil {
+		// attempt to restore the previous static lease so it is not lost
+		restoreErr := r.adg.DhcpAddStaticLease(currentStaticLease)
+		if restoreErr != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating AdGuard Home DHCP Static Lease",
+				"Could not update DHCP static lease, unexpected error: "+err.Error()+
+					"; restoring the previous static lease for "+currentStaticLease.Mac+" also failed, so it no longer exists: "+restoreErr.Error(),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Updating AdGuard Home DHCP Static Lease",
+			"Could not update DHCP static lease, unexpected error: "+err.Error()+
+				"; the previous static lease for "+currentStaticLease.Mac+" was restored",
+		)
+		return
+	}
+
+	// update resource state with updated items and timestamp
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// update state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *dhcpStaticLeaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// retrieve values from state
+	var state dhcpStaticLeaseResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// generate API request body from state
+	var staticLease adgmodels.DhcpStaticLease
+	staticLease.Mac = state.Mac.ValueString()
+	staticLease.Ip = state.Ip.ValueString()
+	staticLease.Hostname = state.Hostname.ValueString()
+
+	// delete existing DHCP static lease
+	err := r.adg.DhcpRemoveStaticLease(staticLease)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting AdGuard Home DHCP Static Lease",
+			"Could not delete DHCP static lease, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *dhcpStaticLeaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
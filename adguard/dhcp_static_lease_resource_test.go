@@ -0,0 +1,163 @@
+package adguard
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDhcpStaticLeaseResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// IP address validation testing
+			{
+				Config: providerConfig + `
+resource "adguard_dhcp_static_lease" "test" {
+  mac      = "00:11:22:33:44:55"
+  ip       = "192.168.250.20/24"
+  hostname = "test-lease"
+}
+`,
+				ExpectError: regexp.MustCompile("must be a valid IPv4 address"),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "adguard_config" "test" {
+	dhcp = {
+		interface = "eth1"
+		ipv4_settings = {
+			gateway_ip     = "192.168.250.1"
+			subnet_mask    = "255.255.255.0"
+			range_start    = "192.168.250.10"
+			range_end      = "192.168.250.100"
+			lease_duration = 7200
+		}
+		manage_static_leases = false
+	}
+}
+
+resource "adguard_dhcp_static_lease" "test" {
+  mac      = "00:11:22:33:44:55"
+  ip       = "192.168.250.20"
+  hostname = "test-lease"
+
+  depends_on = [adguard_config.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_dhcp_static_lease.test", "mac", "00:11:22:33:44:55"),
+					resource.TestCheckResourceAttr("adguard_dhcp_static_lease.test", "ip", "192.168.250.20"),
+					resource.TestCheckResourceAttr("adguard_dhcp_static_lease.test", "hostname", "test-lease"),
+					resource.TestCheckResourceAttr("adguard_dhcp_static_lease.test", "id", "00:11:22:33:44:55"),
+					resource.TestCheckResourceAttr("adguard_config.test", "dhcp.manage_static_leases", "false"),
+					resource.TestCheckNoResourceAttr("adguard_config.test", "dhcp.static_leases"),
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("adguard_dhcp_static_lease.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "adguard_dhcp_static_lease.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The last_updated attribute does not exist in AdGuard Home,
+				// therefore there is no value for it during import
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "adguard_config" "test" {
+	dhcp = {
+		interface = "eth1"
+		ipv4_settings = {
+			gateway_ip     = "192.168.250.1"
+			subnet_mask    = "255.255.255.0"
+			range_start    = "192.168.250.10"
+			range_end      = "192.168.250.100"
+			lease_duration = 7200
+		}
+		manage_static_leases = false
+	}
+}
+
+resource "adguard_dhcp_static_lease" "test" {
+  mac      = "00:11:22:33:44:55"
+  ip       = "192.168.250.30"
+  hostname = "test-lease-updated"
+
+  depends_on = [adguard_config.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_dhcp_static_lease.test", "mac", "00:11:22:33:44:55"),
+					resource.TestCheckResourceAttr("adguard_dhcp_static_lease.test", "ip", "192.168.250.30"),
+					resource.TestCheckResourceAttr("adguard_dhcp_static_lease.test", "hostname", "test-lease-updated"),
+					resource.TestCheckResourceAttr("adguard_dhcp_static_lease.test", "id", "00:11:22:33:44:55"),
+					resource.TestCheckNoResourceAttr("adguard_config.test", "dhcp.static_leases"),
+				),
+			},
+			// equivalent MAC address spelling must not produce a diff
+			{
+				Config: providerConfig + `
+resource "adguard_config" "test" {
+	dhcp = {
+		interface = "eth1"
+		ipv4_settings = {
+			gateway_ip     = "192.168.250.1"
+			subnet_mask    = "255.255.255.0"
+			range_start    = "192.168.250.10"
+			range_end      = "192.168.250.100"
+			lease_duration = 7200
+		}
+		manage_static_leases = false
+	}
+}
+
+resource "adguard_dhcp_static_lease" "test" {
+  mac      = "00-11-22-33-44-55"
+  ip       = "192.168.250.30"
+  hostname = "test-lease-updated"
+
+  depends_on = [adguard_config.test]
+}
+`,
+				PlanOnly: true,
+			},
+			// Replace with a MAC address in a non-canonical spelling testing
+			{
+				Config: providerConfig + `
+resource "adguard_config" "test" {
+	dhcp = {
+		interface = "eth1"
+		ipv4_settings = {
+			gateway_ip     = "192.168.250.1"
+			subnet_mask    = "255.255.255.0"
+			range_start    = "192.168.250.10"
+			range_end      = "192.168.250.100"
+			lease_duration = 7200
+		}
+		manage_static_leases = false
+	}
+}
+
+resource "adguard_dhcp_static_lease" "test" {
+  mac      = "00-11-22-33-44-AA"
+  ip       = "192.168.250.30"
+  hostname = "test-lease-updated"
+
+  depends_on = [adguard_config.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_dhcp_static_lease.test", "mac", "00-11-22-33-44-AA"),
+					resource.TestCheckResourceAttr("adguard_dhcp_static_lease.test", "id", "00:11:22:33:44:aa"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -52,3 +52,27 @@ func GetListFilterByName(adg *adguard.ADG, listName string) (*adgmodels.Filter,
 	// when no matches are found
 	return nil, false, nil
 }
+
+// GetListFilterByUrl - Returns a list filter based on its URL and whether it's a whitelist filter
+func GetListFilterByUrl(adg *adguard.ADG, listUrl string) (*adgmodels.Filter, bool, error) {
+	allFilters, err := adg.FilteringStatus()
+	if err != nil {
+		return nil, false, err
+	}
+
+	// go through the blacklist filters in the response until we find the one we want
+	for _, filterInfo := range allFilters.Filters {
+		if filterInfo.Url == listUrl {
+			return &filterInfo, false, nil
+		}
+	}
+	// go through the whitelist filters in the response until we find the one we want
+	for _, filterInfo := range allFilters.WhitelistFilters {
+		if filterInfo.Url == listUrl {
+			return &filterInfo, true, nil
+		}
+	}
+
+	// when no matches are found
+	return nil, false, nil
+}
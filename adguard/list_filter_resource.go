@@ -32,13 +32,14 @@ type listFilterResource struct {
 
 // listFilterResourceModel maps list filter schema data
 type listFilterResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Url         types.String `tfsdk:"url"`
-	Name        types.String `tfsdk:"name"`
-	LastUpdated types.String `tfsdk:"last_updated"`
-	RulesCount  types.Int64  `tfsdk:"rules_count"`
-	Enabled     types.Bool   `tfsdk:"enabled"`
-	Whitelist   types.Bool   `tfsdk:"whitelist"`
+	ID            types.String `tfsdk:"id"`
+	Url           types.String `tfsdk:"url"`
+	Name          types.String `tfsdk:"name"`
+	LastUpdated   types.String `tfsdk:"last_updated"`
+	RulesCount    types.Int64  `tfsdk:"rules_count"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	Whitelist     types.Bool   `tfsdk:"whitelist"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
 }
 
 // NewlistFilterResource is a helper function to simplify the provider implementation
@@ -90,6 +91,7 @@ func (r *listFilterResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:    true,
 				Default:     booldefault.StaticBool(LIST_FILTER_WHITELIST),
 			},
+			"adopt_existing": adoptExistingResourceSchema("URL or name"),
 		},
 	}
 }
@@ -121,6 +123,51 @@ func (r *listFilterResource) Create(ctx context.Context, req resource.CreateRequ
 	listFilter.Url = plan.Url.ValueString()
 	listFilter.Whitelist = plan.Whitelist.ValueBool()
 
+	if plan.AdoptExisting.ValueBool() {
+		// an existing list filter with the same URL or name is updated instead of created when adopting,
+		// with the URL taking precedence as it identifies the list filter in AdGuard Home
+		existingListFilter, whitelist, err := GetListFilterByUrl(r.adg, listFilter.Url)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Creating AdGuard Home List Filter",
+				"Could not look up existing list filter, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		namedListFilter, namedWhitelist, err := GetListFilterByName(r.adg, listFilter.Name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Creating AdGuard Home List Filter",
+				"Could not look up existing list filter, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		if existingListFilter == nil {
+			existingListFilter, whitelist = namedListFilter, namedWhitelist
+		} else if namedListFilter != nil && (namedListFilter.Id != existingListFilter.Id || namedWhitelist != whitelist) {
+			// adopting either one would collide with or orphan the other
+			resp.Diagnostics.AddError(
+				"Error Creating AdGuard Home List Filter",
+				fmt.Sprintf("Could not adopt existing list filter, as the name %s belongs to the list filter with URL %s "+
+					"while the URL %s belongs to the list filter named %s", listFilter.Name, namedListFilter.Url, listFilter.Url, existingListFilter.Name),
+			)
+			return
+		}
+
+		if existingListFilter != nil {
+			if whitelist != listFilter.Whitelist {
+				resp.Diagnostics.AddError(
+					"Error Creating AdGuard Home List Filter",
+					fmt.Sprintf("Could not adopt existing list filter %s, as its whitelist setting is %t instead of %t", existingListFilter.Name, whitelist, listFilter.Whitelist),
+				)
+				return
+			}
+			r.adopt(ctx, &plan, existingListFilter, resp)
+			return
+		}
+	}
+
 	// create new list filter using plan
 	err := r.adg.FilteringAddUrl(listFilter)
 	if err != nil {
@@ -183,6 +230,49 @@ func (r *listFilterResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 }
 
+// adopt updates an existing list filter to match the plan and takes ownership of it
+func (r *listFilterResource) adopt(ctx context.Context, plan *listFilterResourceModel, existingListFilter *adgmodels.Filter, resp *resource.CreateResponse) {
+	// generate API request body from plan
+	var updateListFilterData adgmodels.FilterSetUrlData
+	updateListFilterData.Enabled = plan.Enabled.ValueBool()
+	updateListFilterData.Name = plan.Name.ValueString()
+	updateListFilterData.Url = plan.Url.ValueString()
+
+	var updateListFilter adgmodels.FilterSetUrl
+	updateListFilter.Url = existingListFilter.Url
+	updateListFilter.Whitelist = plan.Whitelist.ValueBool()
+	updateListFilter.Data = updateListFilterData
+
+	// update existing list filter
+	err := r.adg.FilteringSetUrl(updateListFilter)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating AdGuard Home List Filter",
+			"Could not update adopted list filter, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// refresh the list filter by ID to retrieve the computed attributes
+	adoptedListFilter, _, err := GetListFilterById(r.adg, existingListFilter.Id)
+	if err != nil || adoptedListFilter == nil {
+		resp.Diagnostics.AddError(
+			"Error Creating AdGuard Home List Filter",
+			fmt.Sprintf("Could not read adopted list filter with id %d, unexpected error: %v", existingListFilter.Id, err),
+		)
+		return
+	}
+
+	// update plan with computed attributes
+	plan.ID = types.StringValue(strconv.FormatInt(adoptedListFilter.Id, 10))
+	plan.LastUpdated = types.StringValue(adoptedListFilter.LastUpdated)
+	plan.RulesCount = types.Int64Value(int64(adoptedListFilter.RulesCount))
+
+	// set state to fully populated data
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
 // Read refreshes the Terraform state with the latest data
 func (r *listFilterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// get current state
@@ -243,6 +333,10 @@ func (r *listFilterResource) Read(ctx context.Context, req resource.ReadRequest,
 	state.Url = types.StringValue(listFilter.Url)
 	state.RulesCount = types.Int64Value(int64(listFilter.RulesCount))
 	state.Whitelist = types.BoolValue(whitelist)
+	// the adopt existing flag is null after an import
+	if state.AdoptExisting.IsNull() {
+		state.AdoptExisting = types.BoolValue(ADOPT_EXISTING)
+	}
 
 	// set refreshed state
 	diags = resp.State.Set(ctx, &state)
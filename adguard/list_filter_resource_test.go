@@ -1,8 +1,10 @@
 package adguard
 
 import (
+	"regexp"
 	"testing"
 
+	adgmodels "github.com/gmichels/adguard-client-go/models"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -26,6 +28,7 @@ resource "adguard_list_filter" "test_blacklist" {
 					resource.TestCheckResourceAttrSet("adguard_list_filter.test_blacklist", "id"),
 					resource.TestCheckResourceAttr("adguard_list_filter.test_blacklist", "rules_count", "5"),
 					resource.TestCheckResourceAttr("adguard_list_filter.test_blacklist", "enabled", "true"),
+					resource.TestCheckResourceAttr("adguard_list_filter.test_blacklist", "adopt_existing", "false"),
 					resource.TestCheckResourceAttr("adguard_list_filter.test_blacklist", "whitelist", "false"),
 				),
 			},
@@ -49,6 +52,61 @@ resource "adguard_list_filter" "test_blacklist" {
 					resource.TestCheckResourceAttr("adguard_list_filter.test_blacklist", "rules_count", "8"),
 				),
 			},
+			// Adoption testing, with a list filter created outside of Terraform
+			{
+				PreConfig: func() {
+					adg, err := testAccAdguardClient()
+					if err == nil {
+						err = adg.FilteringAddUrl(adgmodels.AddUrlRequest{
+							Name: "Test Adopted Filter",
+							Url:  "/opt/adguardhome/work/data/userfilters/list_filter_3.txt",
+						})
+					}
+					if err != nil {
+						t.Fatalf("unable to create list filter outside of Terraform: %s", err)
+					}
+				},
+				Config: providerConfig + `
+resource "adguard_list_filter" "test_blacklist" {
+  name = "Test Blacklist Filter Resource Updated"
+  url  = "/opt/adguardhome/work/data/userfilters/list_filter_4.txt"
+}
+
+resource "adguard_list_filter" "test_adopt" {
+  name           = "Test Adopted Filter Renamed"
+  url            = "/opt/adguardhome/work/data/userfilters/list_filter_3.txt"
+  adopt_existing = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_list_filter.test_adopt", "name", "Test Adopted Filter Renamed"),
+					resource.TestCheckResourceAttr("adguard_list_filter.test_adopt", "rules_count", "5"),
+					resource.TestCheckResourceAttr("adguard_list_filter.test_adopt", "adopt_existing", "true"),
+					resource.TestCheckResourceAttrSet("adguard_list_filter.test_adopt", "id"),
+				),
+			},
+			// Adoption conflict testing, with the name and URL belonging to different list filters
+			{
+				Config: providerConfig + `
+resource "adguard_list_filter" "test_blacklist" {
+  name = "Test Blacklist Filter Resource Updated"
+  url  = "/opt/adguardhome/work/data/userfilters/list_filter_4.txt"
+}
+
+resource "adguard_list_filter" "test_adopt" {
+  name           = "Test Adopted Filter Renamed"
+  url            = "/opt/adguardhome/work/data/userfilters/list_filter_3.txt"
+  adopt_existing = true
+}
+
+resource "adguard_list_filter" "test_adopt_conflict" {
+  name           = "Test Blacklist Filter Resource Updated"
+  url            = "/opt/adguardhome/work/data/userfilters/list_filter_3.txt"
+  adopt_existing = true
+}
+`,
+				ExpectError: regexp.MustCompile("Could not adopt existing list filter"),
+			},
 			// Delete testing automatically occurs in TestCase
 
 			// Whitelist
@@ -0,0 +1,58 @@
+package adguard
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// plan modifier keeping the state value of a DNS access rule when the configured value is an equivalent entry,
+// comparing domains for blocked hosts and IP addresses, CIDRs, MACs or ClientIDs for clients
+var _ planmodifier.String = useStateForEquivalentAccessRuleModifier{}
+
+type useStateForEquivalentAccessRuleModifier struct {
+}
+
+func (m useStateForEquivalentAccessRuleModifier) Description(_ context.Context) string {
+	return "keeps the state value when the configured value is an equivalent DNS access list entry"
+}
+
+func (m useStateForEquivalentAccessRuleModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForEquivalentAccessRuleModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() || req.PlanValue.Equal(req.StateValue) {
+		// nothing to compare
+		return
+	}
+
+	var planType, stateType types.String
+
+	diags := req.Plan.GetAttribute(ctx, req.Path.ParentPath().AtName("type"), &planType)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.GetAttribute(ctx, req.Path.ParentPath().AtName("type"), &stateType)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planType.IsUnknown() || !planType.Equal(stateType) {
+		// a different rule type is a different entry
+		return
+	}
+
+	// Terraform accepts the prior value as planned value when it is equivalent to the configured one,
+	// which also prevents any subsequent `RequiresReplace` from triggering
+	if normalizeAccessRuleValue(planType.ValueString(), req.PlanValue.ValueString()) ==
+		normalizeAccessRuleValue(stateType.ValueString(), req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// useStateForEquivalentAccessRule - returns a plan modifier keeping the state value when the configured value
+// is an equivalent DNS access list entry for the rule type. Must be placed before `RequiresReplace`
+func useStateForEquivalentAccessRule() planmodifier.String {
+	return useStateForEquivalentAccessRuleModifier{}
+}
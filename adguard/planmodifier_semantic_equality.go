@@ -0,0 +1,62 @@
+package adguard
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// plan modifier keeping the state value when the configured value is spelled differently but semantically equal
+var _ planmodifier.String = useStateForSemanticEqualityModifier{}
+
+type useStateForSemanticEqualityModifier struct {
+	// custom type providing the semantic equality of its values
+	valueType basetypes.StringTypable
+}
+
+func (m useStateForSemanticEqualityModifier) Description(_ context.Context) string {
+	return "keeps the state value when the configured value is semantically equal to it"
+}
+
+func (m useStateForSemanticEqualityModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForSemanticEqualityModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() || req.PlanValue.Equal(req.StateValue) {
+		// nothing to compare
+		return
+	}
+
+	planValue, diags := m.valueType.ValueFromString(ctx, req.PlanValue)
+	resp.Diagnostics.Append(diags...)
+	stateValue, diags := m.valueType.ValueFromString(ctx, req.StateValue)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	semanticStateValue, ok := stateValue.(basetypes.StringValuableWithSemanticEquals)
+	if !ok {
+		return
+	}
+
+	equal, diags := semanticStateValue.StringSemanticEquals(ctx, planValue)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Terraform accepts the prior value as planned value when it is equivalent to the configured one,
+	// which also prevents any subsequent `RequiresReplace` from triggering
+	if equal {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// useStateForSemanticEquality - returns a plan modifier keeping the state value when the configured value
+// is semantically equal according to the custom type. Must be placed before `RequiresReplace`
+func useStateForSemanticEquality(valueType basetypes.StringTypable) planmodifier.String {
+	return useStateForSemanticEqualityModifier{valueType: valueType}
+}
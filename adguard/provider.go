@@ -2,12 +2,17 @@ package adguard
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"os"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/gmichels/adguard-client-go"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -16,12 +21,21 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // define a max AdGuard Home client timeout
 const MAX_TIMEOUT int = 60
 
+// define the retry defaults and limits for AdGuard Home API requests
+const RETRY_MAX_ATTEMPTS int = 3
+const RETRY_MAX_ATTEMPTS_MAX int = 10
+const RETRY_MIN_BACKOFF int = 1
+const RETRY_MAX_BACKOFF int = 30
+
+var RETRY_STATUS_CODES = []int{429, 502, 503, 504}
+
 // ensure the implementation satisfies the expected interfaces
 var (
 	_ provider.Provider = &adguardProvider{}
@@ -46,12 +60,14 @@ func (p *adguardProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 		Description: "The AdGuard provider provides resources to interact with an [AdGuard Home](https://github.com/AdguardTeam/AdGuardHome) server",
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Description: "The hostname of the AdGuard Home instance. Include the port if not on a standard HTTP/HTTPS port",
-				Optional:    true,
+				Description: "The hostname of the AdGuard Home instance. Include the port if not on a standard HTTP/HTTPS port. " +
+					"Can also be a full URL including the scheme and a path prefix, such as `https://proxy.example.com/adguard`, when AdGuard Home is behind a reverse proxy",
+				Optional: true,
 			},
 			"username": schema.StringAttribute{
-				Description: "The username of the AdGuard Home instance",
-				Optional:    true,
+				Description: "The username of the AdGuard Home instance. The provider logs in once and reuses the session cookie for all requests, " +
+					"falling back to basic authentication when logging in is not available",
+				Optional: true,
 			},
 			"password": schema.StringAttribute{
 				Description: "The password of the AdGuard Home instance",
@@ -80,18 +96,120 @@ func (p *adguardProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Description: "When `true`, will disable any TLS certificate checks. Defaults to `false`",
 				Optional:    true,
 			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the HTTP, HTTPS or SOCKS5 proxy used to reach AdGuard Home, such as `http://proxy.example.com:3128` or `socks5://proxy.example.com:1080`. " +
+					"Takes precedence over the `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment variables, which are honored otherwise",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^(https?|socks5h?)://`),
+						"must be an http, https, socks5 or socks5h URL",
+					),
+				},
+			},
+			"headers": schema.MapAttribute{
+				Description: "Additional HTTP headers sent with every request to AdGuard Home, such as the credentials of a forward authentication layer. " +
+					"Merged with and taking precedence over the headers in the ADGUARD_HEADERS environment variable, formatted as comma-separated `Name=value` pairs. " +
+					"When headers are set, `username` and `password` can be omitted to skip basic authentication",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[A-Za-z0-9-]+$`),
+						"must only contain letters, numbers and hyphens",
+					)),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM-encoded CA certificate bundle trusted for the connection to AdGuard Home, in addition to the system certificates. Conflicts with `ca_cert_file`",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM-encoded CA certificate bundle trusted for the connection to AdGuard Home, in addition to the system certificates. Conflicts with `ca_cert_pem`",
+				Optional:    true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "PEM-encoded client certificate, or path to a file containing it, presented for mutual TLS. Requires `client_key`",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM-encoded private key of the client certificate, or path to a file containing it. Requires `client_cert`",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"retry": schema.SingleNestedAttribute{
+				Description: "Retry settings for transient AdGuard Home API failures, such as connection errors or a reverse proxy error while AdGuard Home restarts. " +
+					"Requests that add, update or delete clients, DHCP static leases, list filters or DNS rewrite rules are only retried when the connection could not be established",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						Description: fmt.Sprintf("Maximum number of attempts for each request, including the first one. Set to `1` to disable retries. Defaults to **%d**", RETRY_MAX_ATTEMPTS),
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, int64(RETRY_MAX_ATTEMPTS_MAX)),
+						},
+					},
+					"min_backoff": schema.Int64Attribute{
+						Description: fmt.Sprintf("Time (in seconds) to wait before the first retry, doubled on each subsequent retry. Defaults to **%d**", RETRY_MIN_BACKOFF),
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, int64(MAX_TIMEOUT)),
+						},
+					},
+					"max_backoff": schema.Int64Attribute{
+						Description: fmt.Sprintf("Maximum time (in seconds) to wait between retries. Defaults to **%d**", RETRY_MAX_BACKOFF),
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, int64(MAX_TIMEOUT)),
+						},
+					},
+					"retryable_status_codes": schema.ListAttribute{
+						Description: fmt.Sprintf("HTTP status codes returned by AdGuard Home, or a reverse proxy in front of it, that trigger a retry. Defaults to `%v`", RETRY_STATUS_CODES),
+						ElementType: types.Int64Type,
+						Optional:    true,
+						Validators: []validator.List{
+							listvalidator.ValueInt64sAre(int64validator.Between(400, 599)),
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
 // adguardProviderModel maps provider schema data to a Go type
 type adguardProviderModel struct {
-	Host     types.String `tfsdk:"host"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
-	Scheme   types.String `tfsdk:"scheme"`
-	Timeout  types.Int64  `tfsdk:"timeout"`
-	Insecure types.Bool   `tfsdk:"insecure"`
+	Host       types.String `tfsdk:"host"`
+	Username   types.String `tfsdk:"username"`
+	Password   types.String `tfsdk:"password"`
+	Scheme     types.String `tfsdk:"scheme"`
+	Timeout    types.Int64  `tfsdk:"timeout"`
+	Insecure   types.Bool   `tfsdk:"insecure"`
+	ProxyUrl   types.String `tfsdk:"proxy_url"`
+	Headers    types.Map    `tfsdk:"headers"`
+	CaCertPem  types.String `tfsdk:"ca_cert_pem"`
+	CaCertFile types.String `tfsdk:"ca_cert_file"`
+	ClientCert types.String `tfsdk:"client_cert"`
+	ClientKey  types.String `tfsdk:"client_key"`
+	Retry      types.Object `tfsdk:"retry"`
+}
+
+// adguardProviderRetryModel maps provider retry schema data to a Go type
+type adguardProviderRetryModel struct {
+	MaxAttempts          types.Int64 `tfsdk:"max_attempts"`
+	MinBackoff           types.Int64 `tfsdk:"min_backoff"`
+	MaxBackoff           types.Int64 `tfsdk:"max_backoff"`
+	RetryableStatusCodes types.List  `tfsdk:"retryable_status_codes"`
 }
 
 // Configure prepares an AdGuard API client for data sources and resources
@@ -162,6 +280,79 @@ func (p *adguardProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.ProxyUrl.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown AdGuard Home Proxy URL",
+			"The provider cannot create the AdGuard Home client as there is an unknown configuration value for the AdGuard Home proxy URL. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the ADGUARD_PROXY_URL environment variable.",
+		)
+	}
+
+	if config.Headers.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("headers"),
+			"Unknown AdGuard Home Headers",
+			"The provider cannot create the AdGuard Home client as there is an unknown configuration value for the AdGuard Home headers. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the ADGUARD_HEADERS environment variable.",
+		)
+	}
+
+	if config.CaCertPem.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_pem"),
+			"Unknown AdGuard Home CA Certificate",
+			"The provider cannot create the AdGuard Home client as there is an unknown configuration value for the AdGuard Home CA certificate. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the ADGUARD_CA_CERT_PEM environment variable.",
+		)
+	}
+
+	if config.CaCertFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Unknown AdGuard Home CA Certificate File",
+			"The provider cannot create the AdGuard Home client as there is an unknown configuration value for the AdGuard Home CA certificate file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the ADGUARD_CA_CERT_FILE environment variable.",
+		)
+	}
+
+	if config.ClientCert.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_cert"),
+			"Unknown AdGuard Home Client Certificate",
+			"The provider cannot create the AdGuard Home client as there is an unknown configuration value for the AdGuard Home client certificate. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the ADGUARD_CLIENT_CERT environment variable.",
+		)
+	}
+
+	if config.ClientKey.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_key"),
+			"Unknown AdGuard Home Client Key",
+			"The provider cannot create the AdGuard Home client as there is an unknown configuration value for the AdGuard Home client key. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the ADGUARD_CLIENT_KEY environment variable.",
+		)
+	}
+
+	var retryConfig adguardProviderRetryModel
+	if !config.Retry.IsNull() && !config.Retry.IsUnknown() {
+		diags = config.Retry.As(ctx, &retryConfig, basetypes.ObjectAsOptions{})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if config.Retry.IsUnknown() || retryConfig.MaxAttempts.IsUnknown() || retryConfig.MinBackoff.IsUnknown() ||
+		retryConfig.MaxBackoff.IsUnknown() || retryConfig.RetryableStatusCodes.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry"),
+			"Unknown AdGuard Home Retry Settings",
+			"The provider cannot create the AdGuard Home client as there is an unknown configuration value for the AdGuard Home retry settings. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -217,6 +408,22 @@ func (p *adguardProvider) Configure(ctx context.Context, req provider.ConfigureR
 		}
 	}
 
+	proxyUrl := os.Getenv("ADGUARD_PROXY_URL")
+
+	headers, err := parseHeaders(os.Getenv("ADGUARD_HEADERS"))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("headers"),
+			"Unable to parse AdGuard Home Headers value",
+			"The provider cannot create the AdGuard Home client as it was unable to parse the provided value for ADGUARD_HEADERS: "+err.Error())
+		return
+	}
+
+	caCertPem := os.Getenv("ADGUARD_CA_CERT_PEM")
+	caCertFile := os.Getenv("ADGUARD_CA_CERT_FILE")
+	clientCert := os.Getenv("ADGUARD_CLIENT_CERT")
+	clientKey := os.Getenv("ADGUARD_CLIENT_KEY")
+
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
 	}
@@ -241,6 +448,38 @@ func (p *adguardProvider) Configure(ctx context.Context, req provider.ConfigureR
 		insecure = config.Insecure.ValueBool()
 	}
 
+	if !config.ProxyUrl.IsNull() {
+		proxyUrl = config.ProxyUrl.ValueString()
+	}
+
+	if !config.Headers.IsNull() {
+		var configHeaders map[string]string
+		diags = config.Headers.ElementsAs(ctx, &configHeaders, false)
+		resp.Diagnostics.Append(diags...)
+		for name, value := range configHeaders {
+			headers[name] = value
+		}
+	}
+
+	// a CA certificate in the configuration takes precedence over both environment variables
+	if !config.CaCertPem.IsNull() {
+		caCertPem = config.CaCertPem.ValueString()
+		caCertFile = ""
+	}
+
+	if !config.CaCertFile.IsNull() {
+		caCertFile = config.CaCertFile.ValueString()
+		caCertPem = ""
+	}
+
+	if !config.ClientCert.IsNull() {
+		clientCert = config.ClientCert.ValueString()
+	}
+
+	if !config.ClientKey.IsNull() {
+		clientKey = config.ClientKey.ValueString()
+	}
+
 	// if any of the expected configurations are missing, return errors with provider-specific guidance
 	if host == "" {
 		resp.Diagnostics.AddAttributeError(
@@ -252,23 +491,88 @@ func (p *adguardProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
-	if username == "" {
+	// split a host provided as a full URL into its scheme and host with path prefix
+	host, hostScheme, err := parseHostUrl(host)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host"),
+			"Unable to parse AdGuard Home Host value",
+			"The provider cannot create the AdGuard Home client as it was unable to parse the provided host URL: "+err.Error())
+		return
+	}
+	if hostScheme != "" {
+		if scheme != "" && scheme != hostScheme {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("scheme"),
+				"Conflicting AdGuard Home Scheme",
+				"The provider cannot create the AdGuard Home client as the scheme `"+scheme+"` does not match the scheme of the host URL `"+hostScheme+"`. "+
+					"Remove the scheme value from the configuration and the ADGUARD_SCHEME environment variable, or ensure both match.",
+			)
+		}
+		scheme = hostScheme
+	}
+
+	// basic authentication is not needed when relying on headers, such as for a forward authentication layer
+	skipBasicAuth := username == "" && password == "" && len(headers) > 0
+
+	if username == "" && !skipBasicAuth {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("username"),
 			"Missing AdGuard Home Username",
 			"The provider cannot create the AdGuard Home client as there is a missing or empty value for the AdGuard Home username. "+
 				"Set the username value in the configuration or use the ADGUARD_USERNAME environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"If either is already set, ensure the value is not empty. "+
+				"Basic authentication can only be skipped when no password is set and headers are provided.",
 		)
 	}
 
-	if password == "" {
+	if password == "" && !skipBasicAuth {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("password"),
 			"Missing AdGuard Home Password",
 			"The provider cannot create the AdGuard Home client as there is a missing or empty value for the AdGuard Home password. "+
 				"Set the password value in the configuration or use the ADGUARD_PASSWORD environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"If either is already set, ensure the value is not empty. "+
+				"Basic authentication can only be skipped when no username is set and headers are provided.",
+		)
+	}
+
+	var proxy *url.URL
+	if proxyUrl != "" {
+		proxy, err = parseProxyUrl(proxyUrl)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Unable to parse AdGuard Home Proxy URL value",
+				"The provider cannot create the AdGuard Home client as it was unable to parse the provided proxy URL: "+err.Error(),
+			)
+		}
+	}
+
+	if caCertPem != "" && caCertFile != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_pem"),
+			"Conflicting AdGuard Home CA Certificate",
+			"The provider cannot create the AdGuard Home client as both a CA certificate and a CA certificate file are provided. "+
+				"Use either ADGUARD_CA_CERT_PEM or ADGUARD_CA_CERT_FILE, but not both.",
+		)
+	}
+
+	if (clientCert == "") != (clientKey == "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_cert"),
+			"Incomplete AdGuard Home Client Certificate",
+			"The provider cannot create the AdGuard Home client as mutual TLS requires both a client certificate and a client key. "+
+				"Set both values in the configuration or use the ADGUARD_CLIENT_CERT and ADGUARD_CLIENT_KEY environment variables.",
+		)
+	}
+
+	// load the PEM contents from files where needed
+	tlsConfig, err := loadTlsSettings(caCertPem, caCertFile, clientCert, clientKey)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Load AdGuard Home TLS Certificates",
+			"The provider cannot create the AdGuard Home client as it was unable to load the provided certificates: "+err.Error(),
 		)
 	}
 
@@ -282,6 +586,41 @@ func (p *adguardProvider) Configure(ctx context.Context, req provider.ConfigureR
 		timeout = 10
 	}
 
+	// set defaults for retry, overridden by the configuration values if set
+	retry := retrySettings{
+		maxAttempts:          RETRY_MAX_ATTEMPTS,
+		minBackoff:           time.Duration(RETRY_MIN_BACKOFF) * time.Second,
+		maxBackoff:           time.Duration(RETRY_MAX_BACKOFF) * time.Second,
+		retryableStatusCodes: RETRY_STATUS_CODES,
+	}
+
+	if !retryConfig.MaxAttempts.IsNull() {
+		retry.maxAttempts = int(retryConfig.MaxAttempts.ValueInt64())
+	}
+
+	if !retryConfig.MinBackoff.IsNull() {
+		retry.minBackoff = time.Duration(retryConfig.MinBackoff.ValueInt64()) * time.Second
+	}
+
+	if !retryConfig.MaxBackoff.IsNull() {
+		retry.maxBackoff = time.Duration(retryConfig.MaxBackoff.ValueInt64()) * time.Second
+	}
+
+	if !retryConfig.RetryableStatusCodes.IsNull() {
+		var retryableStatusCodes []int
+		diags = retryConfig.RetryableStatusCodes.ElementsAs(ctx, &retryableStatusCodes, false)
+		resp.Diagnostics.Append(diags...)
+		retry.retryableStatusCodes = retryableStatusCodes
+	}
+
+	if retry.minBackoff > retry.maxBackoff {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry").AtName("min_backoff"),
+			"Invalid AdGuard Home Retry Settings",
+			"The provider cannot create the AdGuard Home client as the retry `min_backoff` is greater than `max_backoff`.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -293,6 +632,17 @@ func (p *adguardProvider) Configure(ctx context.Context, req provider.ConfigureR
 	ctx = tflog.SetField(ctx, "adguard_scheme", scheme)
 	ctx = tflog.SetField(ctx, "adguard_timeout", timeout)
 	ctx = tflog.SetField(ctx, "adguard_insecure", insecure)
+	if proxy != nil {
+		// the proxy URL can contain credentials
+		ctx = tflog.SetField(ctx, "adguard_proxy_url", proxy.Redacted())
+	}
+	// only log the header names, as their values usually hold credentials
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	ctx = tflog.SetField(ctx, "adguard_headers", headerNames)
+	ctx = tflog.SetField(ctx, "adguard_retry_max_attempts", retry.maxAttempts)
 
 	tflog.Debug(ctx, "Creating AdGuard Home client")
 
@@ -308,6 +658,29 @@ func (p *adguardProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	// go through the proxy, trust the CA bundle and present the client certificate, if provided
+	transport, err := configureTransport(client.HTTPClient.Transport, tlsConfig, proxy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create AdGuard Home Client",
+			"An unexpected error occurred when configuring TLS for the AdGuard Home client.\n\n"+
+				"AdGuard Home Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	// send the additional headers with every request
+	transport = newHeaderTransport(transport, headers)
+
+	// log in once and reuse the session, instead of having AdGuard Home verify the credentials on every request
+	if !skipBasicAuth {
+		transport = newSessionTransport(ctx, transport, scheme+"://"+host+"/control/login", username, password)
+	}
+
+	// retry transient failures on every API call, with the timeout applying to each attempt instead of the whole call
+	client.HTTPClient.Transport = newRetryTransport(ctx, transport, client.HTTPClient.Timeout, retry)
+	client.HTTPClient.Timeout = 0
+
 	// make the AdGuard Home client available during DataSource and Resource type Configure methods
 	resp.DataSourceData = client
 	resp.ResourceData = client
@@ -353,9 +726,15 @@ func (p *adguardProvider) DataSources(_ context.Context) []func() datasource.Dat
 func (p *adguardProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewClientResource,
+		NewClientsPolicyResource,
 		NewListFilterResource,
 		NewUserRulesResource,
 		NewRewriteResource,
+		NewRewritesResource,
 		NewConfigResource,
+		NewDhcpStaticLeaseResource,
+		NewTlsResource,
+		NewAccessRuleResource,
+		NewBlockedServicesResource,
 	}
 }
@@ -1,8 +1,22 @@
 package adguard
 
 import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gmichels/adguard-client-go"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 const (
@@ -41,3 +55,191 @@ var (
 		"adguard": providerserver.NewProtocol6WithError(New()),
 	}
 )
+
+// testAccAdguardClient - returns a client for the AdGuard Home instance of providerConfig, used to
+// restore the Docker fixture after tests changing it
+func testAccAdguardClient() (*adguard.ADG, error) {
+	host := "localhost:8080"
+	username := "admin"
+	password := "SecretP@ssw0rd"
+	scheme := "http"
+	timeout := 30
+	insecure := false
+	return adguard.NewClient(&host, &username, &password, &scheme, &timeout, &insecure)
+}
+
+func TestAccProviderProxy(t *testing.T) {
+	// local stand-in for a forward proxy, counting the requests going through it
+	var proxiedRequests atomic.Int64
+	proxy := httptest.NewServer(&httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			// requests to a forward proxy already hold the absolute URL of the target
+			proxiedRequests.Add(1)
+		},
+	})
+	defer proxy.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing through the proxy
+			{
+				Config: fmt.Sprintf(`
+provider "adguard" {
+  host      = "localhost:8080"
+  username  = "admin"
+  password  = "SecretP@ssw0rd"
+  scheme    = "http"
+  timeout   = 30
+  proxy_url = %q
+}
+
+data "adguard_user_rules" "test" {}`, proxy.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.adguard_user_rules.test", "id"),
+					func(_ *terraform.State) error {
+						if proxiedRequests.Load() == 0 {
+							return errors.New("no request to AdGuard Home went through the proxy")
+						}
+						return nil
+					},
+				),
+			},
+			// Invalid proxy URL testing
+			{
+				Config: `
+provider "adguard" {
+  host      = "localhost:8080"
+  username  = "admin"
+  password  = "SecretP@ssw0rd"
+  scheme    = "http"
+  proxy_url = "ftp://proxy.example.com"
+}
+
+data "adguard_user_rules" "test" {}`,
+				ExpectError: regexp.MustCompile("must be an http, https, socks5 or socks5h URL"),
+			},
+		},
+	})
+}
+
+func TestAccProviderCaCertificateConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Both CA certificate attributes testing
+			{
+				Config: `
+provider "adguard" {
+  host         = "localhost:8080"
+  username     = "admin"
+  password     = "SecretP@ssw0rd"
+  scheme       = "http"
+  ca_cert_pem  = "-----BEGIN CERTIFICATE-----"
+  ca_cert_file = "/etc/ssl/certs/ca.pem"
+}
+
+data "adguard_user_rules" "test" {}`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+func TestAccProviderCaCertificateEnvConflict(t *testing.T) {
+	t.Setenv("ADGUARD_CA_CERT_PEM", "-----BEGIN CERTIFICATE-----")
+	t.Setenv("ADGUARD_CA_CERT_FILE", "/etc/ssl/certs/ca.pem")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Both CA certificate environment variables testing
+			{
+				Config:      providerConfig + `data "adguard_user_rules" "test" {}`,
+				ExpectError: regexp.MustCompile("Conflicting AdGuard Home CA Certificate"),
+			},
+		},
+	})
+}
+
+func TestAccProviderSchemeConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Scheme not matching the host URL testing
+			{
+				Config: `
+provider "adguard" {
+  host     = "https://localhost:8080"
+  username = "admin"
+  password = "SecretP@ssw0rd"
+  scheme   = "http"
+}
+
+data "adguard_user_rules" "test" {}`,
+				ExpectError: regexp.MustCompile("Conflicting AdGuard Home Scheme"),
+			},
+		},
+	})
+}
+
+func TestAccProviderPathPrefix(t *testing.T) {
+	// local stand-in for a reverse proxy serving AdGuard Home under a path prefix
+	target, err := url.Parse("http://localhost:8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	var paths []string
+	proxy := httptest.NewServer(&httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			mu.Lock()
+			paths = append(paths, r.In.URL.Path)
+			mu.Unlock()
+			r.SetURL(target)
+			r.Out.URL.Path = strings.TrimPrefix(r.In.URL.Path, "/adguard")
+		},
+	})
+	defer proxy.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing through the path prefix
+			{
+				Config: fmt.Sprintf(`
+provider "adguard" {
+  host     = "%s/adguard/"
+  username = "admin"
+  password = "SecretP@ssw0rd"
+  timeout  = 30
+}
+
+data "adguard_user_rules" "test" {}`, proxy.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.adguard_user_rules.test", "id"),
+					func(_ *terraform.State) error {
+						mu.Lock()
+						defer mu.Unlock()
+
+						var login, api bool
+						for _, path := range paths {
+							switch {
+							case path == "/adguard/control/login":
+								login = true
+							case strings.HasPrefix(path, "/adguard/control/"):
+								api = true
+							default:
+								return fmt.Errorf("request to %s does not carry the path prefix", path)
+							}
+						}
+						if !login || !api {
+							return fmt.Errorf("expected both a login and an API request under the path prefix, got %v", paths)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,548 @@
+package adguard
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// AdGuard Home API endpoints that are not safe to replay, as a successful first attempt
+// would make the retry fail or create a duplicate
+var nonIdempotentEndpoints = []string{
+	"/clients/add",
+	"/clients/delete",
+	"/clients/update",
+	"/dhcp/add_static_lease",
+	"/dhcp/remove_static_lease",
+	"/filtering/add_url",
+	"/filtering/remove_url",
+	"/filtering/set_url",
+	"/rewrite/add",
+	"/rewrite/delete",
+	"/rewrite/update",
+}
+
+// retrySettings holds the resolved retry configuration of the provider
+type retrySettings struct {
+	maxAttempts          int
+	minBackoff           time.Duration
+	maxBackoff           time.Duration
+	retryableStatusCodes []int
+}
+
+// parseHostUrl - returns the host with its path prefix and the scheme, when the host is provided as a full URL
+func parseHostUrl(host string) (string, string, error) {
+	if !strings.Contains(host, "://") {
+		return strings.TrimSuffix(host, "/"), "", nil
+	}
+
+	hostUrl, err := url.Parse(host)
+	if err != nil {
+		return "", "", err
+	}
+	if hostUrl.Scheme != "http" && hostUrl.Scheme != "https" {
+		return "", "", errors.New("the scheme needs to be either `http` or `https`")
+	}
+	if hostUrl.Host == "" {
+		return "", "", errors.New("the URL does not contain a host")
+	}
+	if hostUrl.User != nil || hostUrl.RawQuery != "" || hostUrl.Fragment != "" {
+		return "", "", errors.New("the URL cannot contain credentials, a query or a fragment")
+	}
+
+	return hostUrl.Host + strings.TrimSuffix(hostUrl.Path, "/"), hostUrl.Scheme, nil
+}
+
+// parseHeaders - parses comma-separated `Name=value` pairs into a map of headers
+func parseHeaders(value string) (map[string]string, error) {
+	headers := make(map[string]string)
+	if strings.TrimSpace(value) == "" {
+		return headers, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		name, headerValue, found := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("expected a `Name=value` pair, got %q", pair)
+		}
+		headers[name] = strings.TrimSpace(headerValue)
+	}
+
+	return headers, nil
+}
+
+// headerTransport is an http.RoundTripper adding headers to every AdGuard Home API request
+type headerTransport struct {
+	next    http.RoundTripper
+	headers map[string]string
+}
+
+// newHeaderTransport wraps an http.RoundTripper with the headers to add
+func newHeaderTransport(next http.RoundTripper, headers map[string]string) *headerTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &headerTransport{
+		next:    next,
+		headers: headers,
+	}
+}
+
+// RoundTrip adds the headers to the request, dropping empty basic authentication credentials
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the request must not be modified, so work on a copy
+	req = req.Clone(req.Context())
+
+	// the AdGuard Home client always sets basic authentication, which is empty when relying on headers only
+	if username, password, ok := req.BasicAuth(); ok && username == "" && password == "" {
+		req.Header.Del("Authorization")
+	}
+
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// sessionTransport is an http.RoundTripper authenticating AdGuard Home API requests with a session cookie
+// instead of basic authentication, logging in again when the session expires
+type sessionTransport struct {
+	// context of the provider configuration, only used for logging
+	ctx      context.Context
+	next     http.RoundTripper
+	loginUrl string
+	username string
+	password string
+
+	mu      sync.Mutex
+	cookies []*http.Cookie
+	// incremented on every login, so concurrent requests with an expired session only log in once
+	generation int
+	// set when AdGuard Home rejects the login, so basic authentication is used from then on
+	unavailable bool
+}
+
+// newSessionTransport wraps an http.RoundTripper, logging in with the credentials on the first request
+func newSessionTransport(ctx context.Context, next http.RoundTripper, loginUrl string, username string, password string) *sessionTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &sessionTransport{
+		ctx:      ctx,
+		next:     next,
+		loginUrl: loginUrl,
+		username: username,
+		password: password,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction with the session cookie, falling back to basic authentication
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cookies, generation := t.session(req, false, 0)
+	if cookies == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	resp, err := t.next.RoundTrip(withSession(req, cookies))
+	// only 401 means the session expired, 403 is returned for requests the session is not allowed to make
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// the session expired, but the request can only be replayed if its body can be read again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	tflog.Debug(t.ctx, "AdGuard Home session expired, logging in again", map[string]any{
+		"path":        req.URL.Path,
+		"status_code": resp.StatusCode,
+	})
+
+	replayReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		replayReq.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cookies, _ = t.session(req, true, generation)
+	if cookies == nil {
+		return t.next.RoundTrip(replayReq)
+	}
+	return t.next.RoundTrip(withSession(replayReq, cookies))
+}
+
+// session - returns the session cookies, logging in when there is no session yet or when renewing an expired one.
+// Returns nil when basic authentication must be used instead
+func (t *sessionTransport) session(req *http.Request, renew bool, expiredGeneration int) ([]*http.Cookie, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.unavailable {
+		return nil, t.generation
+	}
+
+	// another request may have already renewed the session
+	if t.cookies != nil && (!renew || t.generation != expiredGeneration) {
+		return t.cookies, t.generation
+	}
+
+	cookies, statusCode, err := t.login(req.Context())
+	if err != nil {
+		// rejected logins will not succeed later on, unlike transient failures
+		t.unavailable = statusCode >= 400 && statusCode < 500 && statusCode != http.StatusTooManyRequests
+		tflog.Warn(t.ctx, "Unable to log in to AdGuard Home, falling back to basic authentication", map[string]any{
+			"error":       err.Error(),
+			"status_code": statusCode,
+		})
+		t.cookies = nil
+		return nil, t.generation
+	}
+
+	t.cookies = cookies
+	t.generation++
+	tflog.Debug(t.ctx, "Logged in to AdGuard Home", map[string]any{"generation": t.generation})
+
+	return t.cookies, t.generation
+}
+
+// login - logs in to AdGuard Home, returning the session cookies and the status code of the response
+func (t *sessionTransport) login(ctx context.Context) ([]*http.Cookie, int, error) {
+	body, err := json.Marshal(map[string]string{
+		"name":     t.username,
+		"password": t.password,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.loginUrl, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode, fmt.Errorf("login failed with status code %d", resp.StatusCode)
+	}
+	if len(resp.Cookies()) == 0 {
+		return nil, resp.StatusCode, errors.New("login response did not contain a session cookie")
+	}
+
+	return resp.Cookies(), resp.StatusCode, nil
+}
+
+// withSession - returns a copy of the request authenticated with the session cookies instead of basic authentication
+func withSession(req *http.Request, cookies []*http.Cookie) *http.Request {
+	req = req.Clone(req.Context())
+	if _, _, ok := req.BasicAuth(); ok {
+		req.Header.Del("Authorization")
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	return req
+}
+
+// tlsSettings holds the resolved TLS configuration of the provider, with all values as PEM contents
+type tlsSettings struct {
+	caCertPem  string
+	clientCert string
+	clientKey  string
+}
+
+// loadTlsSettings - resolves the TLS configuration, reading the CA bundle file and any client certificate or key given as a path
+func loadTlsSettings(caCertPem string, caCertFile string, clientCert string, clientKey string) (tlsSettings, error) {
+	var err error
+	settings := tlsSettings{caCertPem: caCertPem}
+
+	if caCertFile != "" {
+		settings.caCertPem, err = readPemFile(caCertFile)
+		if err != nil {
+			return settings, err
+		}
+	}
+
+	settings.clientCert, err = readPemOrFile(clientCert)
+	if err != nil {
+		return settings, err
+	}
+
+	settings.clientKey, err = readPemOrFile(clientKey)
+	if err != nil {
+		return settings, err
+	}
+
+	return settings, nil
+}
+
+// readPemOrFile - returns the value as is when it holds PEM contents, otherwise reads it as a file path
+func readPemOrFile(value string) (string, error) {
+	if value == "" || strings.Contains(value, "-----BEGIN") {
+		return value, nil
+	}
+	return readPemFile(value)
+}
+
+// readPemFile - returns the contents of a PEM file
+func readPemFile(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	return string(contents), nil
+}
+
+// configureTransport - returns a copy of the transport going through the proxy, trusting the CA bundle
+// and presenting the client certificate, if provided
+func configureTransport(next http.RoundTripper, settings tlsSettings, proxy *url.URL) (http.RoundTripper, error) {
+	var transport *http.Transport
+	switch t := next.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		// keep the existing TLS settings, such as skipping verification when insecure
+		transport = t.Clone()
+	default:
+		return nil, fmt.Errorf("unsupported HTTP transport %T", next)
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+
+	// the proxy URL takes precedence over the proxy environment variables
+	transport.Proxy = http.ProxyFromEnvironment
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if settings.caCertPem != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM([]byte(settings.caCertPem)) {
+			return nil, errors.New("no valid PEM-encoded certificate found in the CA certificate bundle")
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+
+	if settings.clientCert != "" {
+		certificate, err := tls.X509KeyPair([]byte(settings.clientCert), []byte(settings.clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return transport, nil
+}
+
+// parseProxyUrl - parses the URL of an HTTP, HTTPS or SOCKS5 proxy
+func parseProxyUrl(proxyUrl string) (*url.URL, error) {
+	proxy, err := url.Parse(proxyUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if !contains([]string{"http", "https", "socks5", "socks5h"}, proxy.Scheme) || proxy.Host == "" {
+		return nil, errors.New("invalid proxy URL: expected a URL such as `http://proxy.example.com:3128` or `socks5://proxy.example.com:1080`")
+	}
+	return proxy, nil
+}
+
+// retryTransport is an http.RoundTripper retrying transient AdGuard Home API failures
+// with an exponential backoff
+type retryTransport struct {
+	// context of the provider configuration, only used for logging
+	ctx      context.Context
+	next     http.RoundTripper
+	timeout  time.Duration
+	settings retrySettings
+}
+
+// newRetryTransport wraps an http.RoundTripper, applying the timeout to each attempt
+func newRetryTransport(ctx context.Context, next http.RoundTripper, timeout time.Duration, settings retrySettings) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &retryTransport{
+		ctx:      ctx,
+		next:     next,
+		timeout:  timeout,
+		settings: settings,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction, retrying it when it fails with a transient error
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		attemptReq, cancel, err := t.newAttemptRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+
+		reason := t.retryReason(req, resp, err)
+		if reason == "" || attempt >= t.settings.maxAttempts || (req.Body != nil && req.GetBody == nil) {
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			// the attempt timeout must cover reading the body as well
+			resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		backoff := t.backoff(attempt, resp)
+
+		// discard the failed response so the connection can be reused
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		cancel()
+
+		tflog.Warn(t.ctx, "Retrying AdGuard Home API request", map[string]any{
+			"method":       req.Method,
+			"path":         req.URL.Path,
+			"attempt":      attempt,
+			"max_attempts": t.settings.maxAttempts,
+			"backoff":      backoff.String(),
+			"reason":       reason,
+		})
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// newAttemptRequest - returns a copy of the request with a fresh body and its own timeout
+func (t *retryTransport) newAttemptRequest(req *http.Request, attempt int) (*http.Request, context.CancelFunc, error) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if t.timeout > 0 {
+		ctx, cancel = context.WithTimeout(req.Context(), t.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(req.Context())
+	}
+
+	attemptReq := req.Clone(ctx)
+	if attempt > 1 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		attemptReq.Body = body
+	}
+
+	return attemptReq, cancel, nil
+}
+
+// retryReason - returns why the attempt should be retried, or an empty string when it should not
+func (t *retryTransport) retryReason(req *http.Request, resp *http.Response, err error) string {
+	// the request was not sent at all, so it is always safe to retry
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return err.Error()
+	}
+
+	// other failures may happen after AdGuard Home has processed the request
+	if isNonIdempotentRequest(req) {
+		return ""
+	}
+
+	if err != nil {
+		// the request itself was cancelled, so there is no point in retrying
+		if req.Context().Err() != nil {
+			return ""
+		}
+		return err.Error()
+	}
+
+	for _, statusCode := range t.settings.retryableStatusCodes {
+		if resp.StatusCode == statusCode {
+			return "status code " + strconv.Itoa(resp.StatusCode)
+		}
+	}
+
+	return ""
+}
+
+// backoff - returns the time to wait after a failed attempt, honoring the Retry-After header if present
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	backoff := t.settings.minBackoff << (attempt - 1)
+	// guard against overflow on a large number of attempts
+	if backoff <= 0 || backoff > t.settings.maxBackoff {
+		backoff = t.settings.maxBackoff
+	}
+
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			backoff = min(time.Duration(seconds)*time.Second, t.settings.maxBackoff)
+		}
+	}
+
+	return backoff
+}
+
+// isNonIdempotentRequest - returns whether the request targets an endpoint that is not safe to replay
+func isNonIdempotentRequest(req *http.Request) bool {
+	if req.Method != http.MethodPost {
+		return false
+	}
+	for _, endpoint := range nonIdempotentEndpoints {
+		if strings.HasSuffix(req.URL.Path, endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// cancelOnCloseBody releases the context of an attempt once its response body is closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the response body and cancels the attempt context
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
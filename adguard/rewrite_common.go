@@ -15,7 +15,7 @@ func GetRewrite(adg *adguard.ADG, domain, answer string) (*adgmodels.RewriteEntr
 
 	// loop over the results until we find the one we want
 	for _, rewrite := range *allRewrites {
-		if rewrite.Domain == domain && rewrite.Answer == answer {
+		if normalizeDomain(rewrite.Domain) == normalizeDomain(domain) && rewrite.Answer == answer {
 			return &rewrite, nil
 		}
 	}
@@ -23,3 +23,29 @@ func GetRewrite(adg *adguard.ADG, domain, answer string) (*adgmodels.RewriteEntr
 	// when no matches are found
 	return nil, nil
 }
+
+// GetRewriteByDomain - Return a DNS rewrite rule based on the domain only, preferring the one
+// with the given answer when the domain has several
+func GetRewriteByDomain(adg *adguard.ADG, domain, answer string) (*adgmodels.RewriteEntry, error) {
+	// retrieve all DNS rewrite rules
+	allRewrites, err := adg.RewriteList()
+	if err != nil {
+		return nil, err
+	}
+
+	// loop over the results, keeping the first one with the same domain
+	var match *adgmodels.RewriteEntry
+	for _, rewrite := range *allRewrites {
+		if normalizeDomain(rewrite.Domain) != normalizeDomain(domain) {
+			continue
+		}
+		if rewrite.Answer == answer {
+			return &rewrite, nil
+		}
+		if match == nil {
+			match = &rewrite
+		}
+	}
+
+	return match, nil
+}
@@ -25,7 +25,7 @@ type rewriteDataSource struct {
 // rewriteDataModel maps rewrite schema data
 type rewriteDataModel struct {
 	ID      types.String `tfsdk:"id"`
-	Domain  types.String `tfsdk:"domain"`
+	Domain  domainValue  `tfsdk:"domain"`
 	Answer  types.String `tfsdk:"answer"`
 	Enabled types.Bool   `tfsdk:"enabled"`
 }
@@ -49,7 +49,8 @@ func (d *rewriteDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 				Computed:    true,
 			},
 			"domain": schema.StringAttribute{
-				Description: "Domain name",
+				Description: "Domain name, in Unicode or punycode",
+				CustomType:  domainType{},
 				Required:    true,
 			},
 			"answer": schema.StringAttribute{
@@ -103,7 +104,7 @@ func (d *rewriteDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	// map response body to model
-	state.Domain = types.StringValue(rewrite.Domain)
+	state.Domain = newDomainValue(rewrite.Domain)
 	state.Answer = types.StringValue(rewrite.Answer)
 	state.Enabled = types.BoolValue(rewrite.Enabled)
 
@@ -1,3 +1,5 @@
 package adguard
 
 const REWRITE_ENABLED = true
+
+const REWRITES_EXCLUSIVE = false
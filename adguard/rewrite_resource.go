@@ -27,6 +27,7 @@ var (
 	_ resource.Resource                = &rewriteResource{}
 	_ resource.ResourceWithConfigure   = &rewriteResource{}
 	_ resource.ResourceWithImportState = &rewriteResource{}
+	_ resource.ResourceWithModifyPlan  = &rewriteResource{}
 )
 
 // rewriteResource is the resource implementation
@@ -36,11 +37,12 @@ type rewriteResource struct {
 
 // rewriteResourceModel maps DNS rewrite rule schema data
 type rewriteResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	LastUpdated types.String `tfsdk:"last_updated"`
-	Domain      types.String `tfsdk:"domain"`
-	Answer      types.String `tfsdk:"answer"`
-	Enabled     types.Bool   `tfsdk:"enabled"`
+	ID            types.String `tfsdk:"id"`
+	LastUpdated   types.String `tfsdk:"last_updated"`
+	Domain        domainValue  `tfsdk:"domain"`
+	Answer        types.String `tfsdk:"answer"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
 }
 
 // NewRewriteResource is a helper function to simplify the provider implementation
@@ -69,18 +71,19 @@ func (r *rewriteResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed:    true,
 			},
 			"domain": schema.StringAttribute{
-				Description: "Domain name",
+				Description: "Domain name, in Unicode or punycode. A wildcard is allowed as the leftmost label, as in `*.example.org`",
+				CustomType:  domainType{},
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					useStateForSemanticEquality(domainType{}),
+				},
+				Validators: []validator.String{
+					checkDomain(),
 				},
 			},
 			"answer": schema.StringAttribute{
 				Description: "Value of A, AAAA or CNAME DNS record",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 				Validators: []validator.String{
 					stringvalidator.RegexMatches(
 						regexp.MustCompile(`^[A-Za-z0-9/.:-]+$`),
@@ -94,10 +97,41 @@ func (r *rewriteResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Default:     booldefault.StaticBool(REWRITE_ENABLED),
 			},
+			"adopt_existing": adoptExistingResourceSchema("domain"),
 		},
 	}
 }
 
+// ModifyPlan marks the ID as unknown when the domain or answer changes, as it is derived from both
+func (r *rewriteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// if plan or state are null, then this is a create or destroy and there is nothing to modify
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	// retrieve plan and state
+	var plan rewriteResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state rewriteResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the domain or answer will be updated in place, so the ID will change,
+	// unless the domain is only spelled differently
+	if normalizeDomain(plan.Domain.ValueString()) != normalizeDomain(state.Domain.ValueString()) || !plan.Answer.Equal(state.Answer) {
+		diags = resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())
+		resp.Diagnostics.Append(diags...)
+	}
+}
+
 // Configure adds the provider configured DNS rewrite rule to the resource
 func (r *rewriteResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -125,14 +159,40 @@ func (r *rewriteResource) Create(ctx context.Context, req resource.CreateRequest
 	rewrite.Answer = plan.Answer.ValueString()
 	rewrite.Enabled = plan.Enabled.ValueBool()
 
-	// create new DNS rewrite rule using plan
-	err := r.adg.RewriteAdd(rewrite)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating DNS Rewrite Rule",
-			"Could not create DNS rewrite rule, unexpected error: "+err.Error(),
-		)
-		return
+	// an existing DNS rewrite rule with the same domain is updated instead of created when adopting
+	var existingRewrite *adgmodels.RewriteEntry
+	if plan.AdoptExisting.ValueBool() {
+		var err error
+		existingRewrite, err = GetRewriteByDomain(r.adg, rewrite.Domain, rewrite.Answer)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Creating DNS Rewrite Rule",
+				"Could not look up existing DNS rewrite rule, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if existingRewrite == nil {
+		// create new DNS rewrite rule using plan
+		err := r.adg.RewriteAdd(rewrite)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Creating DNS Rewrite Rule",
+				"Could not create DNS rewrite rule, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	} else if *existingRewrite != rewrite {
+		// update the adopted DNS rewrite rule to match the plan
+		err := r.adg.RewriteUpdate(adgmodels.RewriteUpdate{Target: *existingRewrite, Update: rewrite})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Creating DNS Rewrite Rule",
+				"Could not update existing DNS rewrite rule, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 
 	// add missing attributes for state
@@ -202,9 +262,13 @@ func (r *rewriteResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	// overwrite DNS rewrite rule with refreshed state
-	state.Domain = types.StringValue(rewrite.Domain)
+	state.Domain = newDomainValue(rewrite.Domain)
 	state.Answer = types.StringValue(rewrite.Answer)
 	state.Enabled = types.BoolValue(rewrite.Enabled)
+	// the adopt existing flag is null after an import
+	if state.AdoptExisting.IsNull() {
+		state.AdoptExisting = types.BoolValue(ADOPT_EXISTING)
+	}
 
 	// set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -232,11 +296,25 @@ func (r *rewriteResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// generate API request body from plan and state
-	var updateRewriteTarget adgmodels.RewriteEntry
-	updateRewriteTarget.Domain = state.Domain.ValueString()
-	updateRewriteTarget.Answer = state.Answer.ValueString()
-	updateRewriteTarget.Enabled = state.Enabled.ValueBool()
+	// the state may hold another spelling of the domain, so target the DNS rewrite rule as stored in AdGuard Home
+	currentRewrite, err := GetRewrite(r.adg, state.Domain.ValueString(), state.Answer.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating AdGuard Home DNS Rewrite Rule",
+			"Could not read DNS rewrite rule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if currentRewrite == nil {
+		resp.Diagnostics.AddError(
+			"Error Updating AdGuard Home DNS Rewrite Rule",
+			"Could not update DNS rewrite rule, as no rewrite rule with ID "+state.ID.ValueString()+" exists",
+		)
+		return
+	}
+
+	// generate API request body from plan and the current DNS rewrite rule
+	updateRewriteTarget := *currentRewrite
 
 	var updateRewriteUpdate adgmodels.RewriteEntry
 	updateRewriteUpdate.Domain = plan.Domain.ValueString()
@@ -248,7 +326,7 @@ func (r *rewriteResource) Update(ctx context.Context, req resource.UpdateRequest
 	updateRewrite.Update = updateRewriteUpdate
 
 	// update existing DNS rewrite rule
-	err := r.adg.RewriteUpdate(updateRewrite)
+	err = r.adg.RewriteUpdate(updateRewrite)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating AdGuard Home DNS Rewrite Rule",
@@ -257,7 +335,8 @@ func (r *rewriteResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// update resource state with updated items and timestamp
+	// update resource state with the recomputed ID, updated items and timestamp
+	plan.ID = types.StringValue(updateRewriteUpdate.Domain + "||" + updateRewriteUpdate.Answer)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	// update state
@@ -278,13 +357,27 @@ func (r *rewriteResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	// generate API request body from state
+	// the state may hold another spelling of the domain, so delete the DNS rewrite rule as stored in AdGuard Home
+	currentRewrite, err := GetRewrite(r.adg, state.Domain.ValueString(), state.Answer.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting AdGuard Home DNS Rewrite Rule",
+			"Could not read DNS rewrite rule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if currentRewrite == nil {
+		// already deleted outside of Terraform
+		return
+	}
+
+	// generate API request body from the current DNS rewrite rule
 	var deleteRewrite adgmodels.RewriteEntry
-	deleteRewrite.Domain = state.Domain.ValueString()
-	deleteRewrite.Answer = state.Answer.ValueString()
+	deleteRewrite.Domain = currentRewrite.Domain
+	deleteRewrite.Answer = currentRewrite.Answer
 
 	// delete existing DNS rewrite rule
-	err := r.adg.RewriteDelete(deleteRewrite)
+	err = r.adg.RewriteDelete(deleteRewrite)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting AdGuard Home DNS Rewrite Rule",
@@ -1,15 +1,29 @@
 package adguard
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccRewriteResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// the adoption step takes over the rewrite of the Docker fixture, which other tests rely on
+		CheckDestroy: testAccRestoreFixtureRewrite,
 		Steps: []resource.TestStep{
+			// wildcard not in the leftmost label
+			{
+				Config: providerConfig + `
+resource "adguard_rewrite" "test" {
+  domain = "www.*.example.com"
+  answer = "4.3.2.1"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Domain"),
+			},
 			// Create and Read testing
 			{
 				Config: providerConfig + `
@@ -22,6 +36,7 @@ resource "adguard_rewrite" "test" {
 					resource.TestCheckResourceAttr("adguard_rewrite.test", "domain", "example.com"),
 					resource.TestCheckResourceAttr("adguard_rewrite.test", "answer", "4.3.2.1"),
 					resource.TestCheckResourceAttr("adguard_rewrite.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("adguard_rewrite.test", "adopt_existing", "false"),
 					resource.TestCheckResourceAttr("adguard_rewrite.test", "id", "example.com||4.3.2.1"),
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("adguard_rewrite.test", "last_updated"),
@@ -52,6 +67,108 @@ resource "adguard_rewrite" "test" {
 					resource.TestCheckResourceAttr("adguard_rewrite.test", "id", "example.com||2400:cb00:2049:1::a29f:1804"),
 				),
 			},
+			// Update domain and answer in place
+			{
+				Config: providerConfig + `
+resource "adguard_rewrite" "test" {
+  domain  = "updated.example.com"
+  answer  = "1.2.3.4"
+  enabled = false
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("adguard_rewrite.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_rewrite.test", "domain", "updated.example.com"),
+					resource.TestCheckResourceAttr("adguard_rewrite.test", "answer", "1.2.3.4"),
+					resource.TestCheckResourceAttr("adguard_rewrite.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("adguard_rewrite.test", "id", "updated.example.com||1.2.3.4"),
+				),
+			},
+			// Adoption testing, matching the rewrite of the Docker fixture by domain only
+			{
+				Config: providerConfig + `
+resource "adguard_rewrite" "test" {
+  domain  = "updated.example.com"
+  answer  = "1.2.3.4"
+  enabled = false
+}
+
+resource "adguard_rewrite" "test_adopt" {
+  domain         = "example.org"
+  answer         = "5.6.7.8"
+  adopt_existing = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_rewrite.test_adopt", "id", "example.org||5.6.7.8"),
+					resource.TestCheckResourceAttr("adguard_rewrite.test_adopt", "answer", "5.6.7.8"),
+					resource.TestCheckResourceAttr("adguard_rewrite.test_adopt", "adopt_existing", "true"),
+				),
+			},
+			// Update an internationalized domain, targeting the rewrite rule as stored in AdGuard Home
+			{
+				Config: providerConfig + `
+resource "adguard_rewrite" "test" {
+  domain  = "bücher.example.com"
+  answer  = "1.2.3.4"
+  enabled = false
+}
+
+resource "adguard_rewrite" "test_adopt" {
+  domain         = "example.org"
+  answer         = "5.6.7.8"
+  adopt_existing = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_rewrite.test", "domain", "bücher.example.com"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "adguard_rewrite" "test" {
+  domain  = "bücher.example.com"
+  answer  = "4.3.2.1"
+  enabled = true
+}
+
+resource "adguard_rewrite" "test_adopt" {
+  domain         = "example.org"
+  answer         = "5.6.7.8"
+  adopt_existing = true
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("adguard_rewrite.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_rewrite.test", "answer", "4.3.2.1"),
+					resource.TestCheckResourceAttr("adguard_rewrite.test", "enabled", "true"),
+				),
+			},
+			// respelling the domain in punycode must not produce a diff
+			{
+				Config: providerConfig + `
+resource "adguard_rewrite" "test" {
+  domain  = "xn--bcher-kva.example.com"
+  answer  = "4.3.2.1"
+  enabled = true
+}
+
+resource "adguard_rewrite" "test_adopt" {
+  domain         = "example.org"
+  answer         = "5.6.7.8"
+  adopt_existing = true
+}
+`,
+				PlanOnly: true,
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
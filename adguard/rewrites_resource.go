@@ -0,0 +1,552 @@
+package adguard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gmichels/adguard-client-go"
+	adgmodels "github.com/gmichels/adguard-client-go/models"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &rewritesResource{}
+	_ resource.ResourceWithConfigure   = &rewritesResource{}
+	_ resource.ResourceWithImportState = &rewritesResource{}
+	_ resource.ResourceWithModifyPlan  = &rewritesResource{}
+)
+
+// rewritesResource is the resource implementation
+type rewritesResource struct {
+	adg *adguard.ADG
+}
+
+// rewritesResourceModel maps DNS rewrite rules schema data
+type rewritesResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+	Rewrites    types.Set    `tfsdk:"rewrites"`
+	Exclusive   types.Bool   `tfsdk:"exclusive"`
+}
+
+// rewritesEntryModel maps a single DNS rewrite rule within the rewrites schema data
+type rewritesEntryModel struct {
+	Domain  domainValue  `tfsdk:"domain"`
+	Answer  types.String `tfsdk:"answer"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+// attrTypes - return attribute types for this model
+func (o rewritesEntryModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"domain":  domainType{},
+		"answer":  types.StringType,
+		"enabled": types.BoolType,
+	}
+}
+
+// NewRewritesResource is a helper function to simplify the provider implementation
+func NewRewritesResource() resource.Resource {
+	return &rewritesResource{}
+}
+
+// Metadata returns the resource type name
+func (r *rewritesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rewrites"
+}
+
+// Schema defines the schema for the resource
+func (r *rewritesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier attribute",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the rewrites",
+				Computed:    true,
+			},
+			"rewrites": schema.SetNestedAttribute{
+				Description: "Set of DNS rewrite rules managed by this resource",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain": schema.StringAttribute{
+							Description: "Domain name, in Unicode or punycode. A wildcard is allowed as the leftmost label, as in `*.example.org`",
+							CustomType:  domainType{},
+							Required:    true,
+							Validators: []validator.String{
+								checkDomain(),
+							},
+						},
+						"answer": schema.StringAttribute{
+							Description: "Value of A, AAAA or CNAME DNS record",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(
+									regexp.MustCompile(`^[A-Za-z0-9/.:-]+$`),
+									"must be an IP address/CIDR, MAC address, or only contain numbers, lowercase letters, and hyphens",
+								),
+							},
+						},
+						"enabled": schema.BoolAttribute{
+							Description: fmt.Sprintf("Whether the rewrite rule is enabled. Defaults to `%t`", REWRITE_ENABLED),
+							Computed:    true,
+							Optional:    true,
+							Default:     booldefault.StaticBool(REWRITE_ENABLED),
+						},
+					},
+				},
+			},
+			"exclusive": schema.BoolAttribute{
+				Description: fmt.Sprintf("Whether DNS rewrite rules not managed by this resource are deleted. "+
+					"When `false`, unmanaged rewrite rules are left alone and reported as a warning during plan, "+
+					"and planning a rewrite rule that already exists unmanaged is an error. Defaults to `%t`", REWRITES_EXCLUSIVE),
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(REWRITES_EXCLUSIVE),
+			},
+		},
+	}
+}
+
+// ModifyPlan reports the DNS rewrite rules not managed by this resource
+func (r *rewritesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// if plan is null, then there is no plan to work with
+	if req.Plan.Raw.IsNull() || r.adg == nil {
+		return
+	}
+
+	// retrieve plan
+	var plan rewritesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.Rewrites.IsUnknown() || plan.Exclusive.IsUnknown() {
+		return
+	}
+
+	// retrieve state, which will be null on create operations
+	var state rewritesResourceModel
+	if !req.State.Raw.IsNull() {
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// retrieve all DNS rewrite rules
+	allRewrites, err := r.adg.RewriteList()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read AdGuard Home DNS Rewrite Rules",
+			err.Error(),
+		)
+		return
+	}
+
+	planRewrites := rewritesModelToEntries(ctx, plan.Rewrites, &resp.Diagnostics)
+	stateRewrites := rewritesModelToEntries(ctx, state.Rewrites, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a planned rewrite already present but not managed would be taken over and deleted on destroy,
+	// so only allow this when all rewrites are owned by this resource
+	if !plan.Exclusive.ValueBool() {
+		var existing []string
+		for _, rewrite := range *allRewrites {
+			if containsRewrite(planRewrites, rewrite) && !containsRewrite(stateRewrites, rewrite) {
+				existing = append(existing, rewrite.Domain+" -> "+rewrite.Answer)
+			}
+		}
+		if len(existing) > 0 {
+			sort.Strings(existing)
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rewrites"),
+				"AdGuard Home DNS Rewrite Rules Already Exist",
+				"The following DNS rewrite rules already exist and are not managed by this resource: "+strings.Join(existing, ", ")+
+					". Remove them from the configuration, set `exclusive` to `true` or import the resource to manage them",
+			)
+			return
+		}
+	}
+
+	// rewrites in the state are already shown in the plan as being deleted when exclusive
+	var unmanaged []string
+	for _, rewrite := range *allRewrites {
+		if !containsRewrite(planRewrites, rewrite) && !containsRewrite(stateRewrites, rewrite) {
+			unmanaged = append(unmanaged, rewrite.Domain+" -> "+rewrite.Answer)
+		}
+	}
+	if len(unmanaged) == 0 {
+		return
+	}
+	sort.Strings(unmanaged)
+
+	if plan.Exclusive.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("exclusive"),
+			"Unmanaged AdGuard Home DNS Rewrite Rules Will Be Deleted",
+			"The following DNS rewrite rules are not managed by this resource and will be deleted: "+strings.Join(unmanaged, ", "),
+		)
+	} else {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("rewrites"),
+			"Unmanaged AdGuard Home DNS Rewrite Rules",
+			"The following DNS rewrite rules are not managed by this resource: "+strings.Join(unmanaged, ", "),
+		)
+	}
+}
+
+// Configure adds the provider configured DNS rewrite rules to the resource
+func (r *rewritesResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.adg = req.ProviderData.(*adguard.ADG)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *rewritesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan rewritesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// defer to common function to create or update the resource, nothing is managed yet
+	r.CreateOrUpdate(ctx, &plan, types.SetNull(types.ObjectType{AttrTypes: rewritesEntryModel{}.attrTypes()}), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// there can be only one entry for the entire DNS rewrite rules, so hardcode the ID as 1
+	plan.ID = types.StringValue("1")
+	// add the last updated attribute
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *rewritesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get current state
+	var state rewritesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve all DNS rewrite rules
+	allRewrites, err := r.adg.RewriteList()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read AdGuard Home DNS Rewrite Rules",
+			err.Error(),
+		)
+		return
+	}
+	// convert to JSON for response logging
+	rewritesJson, err := json.Marshal(allRewrites)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse AdGuard Home DNS Rewrite Rules",
+			err.Error(),
+		)
+		return
+	}
+	// log response body
+	tflog.Debug(ctx, "ADG API response", map[string]interface{}{
+		"object": "rewrites",
+		"body":   string(rewritesJson),
+	})
+
+	// the exclusive flag is null after an import
+	if state.Exclusive.IsNull() {
+		state.Exclusive = types.BoolValue(REWRITES_EXCLUSIVE)
+	}
+
+	stateRewrites := rewritesModelToEntries(ctx, state.Rewrites, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// all rewrites are managed when exclusive or after an import, otherwise only the ones already in state
+	var rewrites []rewritesEntryModel
+	for _, rewrite := range *allRewrites {
+		if state.Exclusive.ValueBool() || state.Rewrites.IsNull() || containsRewrite(stateRewrites, rewrite) {
+			rewrites = append(rewrites, rewritesEntryModel{
+				Domain:  newDomainValue(rewrite.Domain),
+				Answer:  types.StringValue(rewrite.Answer),
+				Enabled: types.BoolValue(rewrite.Enabled),
+			})
+		}
+	}
+
+	// overwrite DNS rewrite rules with refreshed state
+	state.Rewrites, diags = types.SetValueFrom(ctx, types.ObjectType{AttrTypes: rewritesEntryModel{}.attrTypes()}, rewrites)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *rewritesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan rewritesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve state as we need the currently managed rewrites
+	var state rewritesResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// defer to common function to create or update the resource
+	r.CreateOrUpdate(ctx, &plan, state.Rewrites, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update resource state with updated items and timestamp
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// update state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *rewritesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// retrieve values from state
+	var state rewritesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stateRewrites := rewritesModelToEntries(ctx, state.Rewrites, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve all DNS rewrite rules, as only existing ones can be deleted
+	allRewrites, err := r.adg.RewriteList()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting AdGuard Home DNS Rewrite Rules",
+			"Could not read current DNS rewrite rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// delete the managed DNS rewrite rules
+	for _, rewrite := range *allRewrites {
+		if !containsRewrite(stateRewrites, rewrite) {
+			continue
+		}
+		err = r.adg.RewriteDelete(rewrite)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home DNS Rewrite Rules",
+				"Could not delete DNS rewrite rule, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+}
+
+func (r *rewritesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// common `Create` and `Update` function for the resource
+func (r *rewritesResource) CreateOrUpdate(ctx context.Context, plan *rewritesResourceModel, stateRewritesSet types.Set, diags *diag.Diagnostics) {
+	planRewrites := rewritesModelToEntries(ctx, plan.Rewrites, diags)
+	stateRewrites := rewritesModelToEntries(ctx, stateRewritesSet, diags)
+	if diags.HasError() {
+		return
+	}
+
+	// retrieve all DNS rewrite rules in a single call to compute the changes
+	allRewrites, err := r.adg.RewriteList()
+	if err != nil {
+		diags.AddError(
+			"Error Updating AdGuard Home DNS Rewrite Rules",
+			"Could not read current DNS rewrite rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	updates, deletes, adds := diffRewrites(*allRewrites, planRewrites, stateRewrites, plan.Exclusive.ValueBool())
+	tflog.Debug(ctx, "DNS rewrite rule changes", map[string]interface{}{
+		"updates": len(updates),
+		"deletes": len(deletes),
+		"adds":    len(adds),
+	})
+
+	for _, rewriteUpdate := range updates {
+		err = r.adg.RewriteUpdate(rewriteUpdate)
+		if err != nil {
+			diags.AddError(
+				"Error Updating AdGuard Home DNS Rewrite Rules",
+				"Could not update DNS rewrite rule for "+rewriteUpdate.Target.Domain+", unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+	for _, rewrite := range deletes {
+		err = r.adg.RewriteDelete(rewrite)
+		if err != nil {
+			diags.AddError(
+				"Error Updating AdGuard Home DNS Rewrite Rules",
+				"Could not delete DNS rewrite rule for "+rewrite.Domain+", unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+	for _, rewrite := range adds {
+		err = r.adg.RewriteAdd(rewrite)
+		if err != nil {
+			diags.AddError(
+				"Error Updating AdGuard Home DNS Rewrite Rules",
+				"Could not add DNS rewrite rule for "+rewrite.Domain+", unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+}
+
+// rewritesModelToEntries - converts a set of rewrites from the schema to DNS rewrite rules
+func rewritesModelToEntries(ctx context.Context, rewritesSet types.Set, diags *diag.Diagnostics) []adgmodels.RewriteEntry {
+	if rewritesSet.IsNull() || rewritesSet.IsUnknown() {
+		return nil
+	}
+
+	var rewriteModels []rewritesEntryModel
+	d := rewritesSet.ElementsAs(ctx, &rewriteModels, false)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil
+	}
+
+	var rewrites []adgmodels.RewriteEntry
+	for _, rewriteModel := range rewriteModels {
+		rewrites = append(rewrites, adgmodels.RewriteEntry{
+			Domain:  rewriteModel.Domain.ValueString(),
+			Answer:  rewriteModel.Answer.ValueString(),
+			Enabled: rewriteModel.Enabled.ValueBool(),
+		})
+	}
+	return rewrites
+}
+
+// rewriteKey - returns the identity of a DNS rewrite rule, which is its domain and answer
+func rewriteKey(rewrite adgmodels.RewriteEntry) string {
+	return normalizeDomain(rewrite.Domain) + "||" + rewrite.Answer
+}
+
+// containsRewrite - returns whether a DNS rewrite rule with the same domain and answer is in the list
+func containsRewrite(rewrites []adgmodels.RewriteEntry, rewrite adgmodels.RewriteEntry) bool {
+	for _, r := range rewrites {
+		if rewriteKey(r) == rewriteKey(rewrite) {
+			return true
+		}
+	}
+	return false
+}
+
+// diffRewrites - computes the calls needed to go from the current to the planned DNS rewrite rules.
+// Current rewrites are only deleted when previously managed or in exclusive mode, and a deleted and
+// an added rewrite for the same domain are combined into a single update so the domain keeps resolving
+func diffRewrites(current []adgmodels.RewriteEntry, planned []adgmodels.RewriteEntry, managed []adgmodels.RewriteEntry, exclusive bool) ([]adgmodels.RewriteUpdate, []adgmodels.RewriteEntry, []adgmodels.RewriteEntry) {
+	var updates []adgmodels.RewriteUpdate
+	var deletes []adgmodels.RewriteEntry
+	var adds []adgmodels.RewriteEntry
+
+	currentByKey := make(map[string]adgmodels.RewriteEntry)
+	for _, rewrite := range current {
+		currentByKey[rewriteKey(rewrite)] = rewrite
+	}
+
+	for _, rewrite := range planned {
+		currentRewrite, found := currentByKey[rewriteKey(rewrite)]
+		if !found {
+			adds = append(adds, rewrite)
+		} else if currentRewrite.Enabled != rewrite.Enabled {
+			updates = append(updates, adgmodels.RewriteUpdate{Target: currentRewrite, Update: rewrite})
+		}
+	}
+
+	for _, rewrite := range current {
+		if containsRewrite(planned, rewrite) || (!exclusive && !containsRewrite(managed, rewrite)) {
+			continue
+		}
+
+		// replace the answer in place when the same domain is being added
+		replaced := false
+		for i, add := range adds {
+			if normalizeDomain(add.Domain) == normalizeDomain(rewrite.Domain) {
+				updates = append(updates, adgmodels.RewriteUpdate{Target: rewrite, Update: add})
+				adds = append(adds[:i], adds[i+1:]...)
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			deletes = append(deletes, rewrite)
+		}
+	}
+
+	return updates, deletes, adds
+}
@@ -0,0 +1,170 @@
+package adguard
+
+import (
+	"regexp"
+	"testing"
+
+	adgmodels "github.com/gmichels/adguard-client-go/models"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRewritesResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// the exclusive step deletes the rewrite of the Docker fixture, which other tests rely on
+		CheckDestroy: testAccRestoreFixtureRewrite,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "adguard_rewrites" "test" {
+  rewrites = [
+    {
+      domain = "rewrites.example.com"
+      answer = "4.3.2.1"
+    },
+    {
+      domain  = "*.rewrites.example.org"
+      answer  = "rewrites.example.com"
+      enabled = false
+    },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_rewrites.test", "id", "1"),
+					resource.TestCheckResourceAttr("adguard_rewrites.test", "exclusive", "false"),
+					resource.TestCheckResourceAttr("adguard_rewrites.test", "rewrites.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("adguard_rewrites.test", "rewrites.*", map[string]string{
+						"domain":  "rewrites.example.com",
+						"answer":  "4.3.2.1",
+						"enabled": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("adguard_rewrites.test", "rewrites.*", map[string]string{
+						"domain":  "*.rewrites.example.org",
+						"answer":  "rewrites.example.com",
+						"enabled": "false",
+					}),
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("adguard_rewrites.test", "last_updated"),
+				),
+			},
+			// planning a rewrite that already exists unmanaged must fail when not exclusive
+			{
+				Config: providerConfig + `
+resource "adguard_rewrites" "test" {
+  rewrites = [
+    {
+      domain = "rewrites.example.com"
+      answer = "4.3.2.1"
+    },
+    {
+      domain  = "*.rewrites.example.org"
+      answer  = "rewrites.example.com"
+      enabled = false
+    },
+    {
+      domain = "example.org"
+      answer = "1.2.3.4"
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile("DNS Rewrite Rules Already Exist"),
+			},
+			// ImportState testing, adopting all rewrites including the one of the Docker fixture
+			{
+				ResourceName:       "adguard_rewrites.test",
+				ImportState:        true,
+				ImportStateId:      "1",
+				ImportStateVerify:  true,
+				ImportStatePersist: true,
+				// The last_updated attribute does not exist in AdGuard Home,
+				// therefore there is no value for it during import.
+				// All rewrites are managed after an import, including the one of the Docker fixture
+				ImportStateVerifyIgnore: []string{"last_updated", "rewrites"},
+			},
+			// the next plan after an import deletes the adopted rewrites missing from the configuration
+			{
+				Config: providerConfig + `
+resource "adguard_rewrites" "test" {
+  rewrites = [
+    {
+      domain = "rewrites.example.com"
+      answer = "4.3.2.1"
+    },
+    {
+      domain  = "*.rewrites.example.org"
+      answer  = "rewrites.example.com"
+      enabled = false
+    },
+  ]
+}
+`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPreRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("adguard_rewrites.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// Update and Read testing, deleting the unmanaged rewrite of the Docker fixture
+			{
+				Config: providerConfig + `
+resource "adguard_rewrites" "test" {
+  rewrites = [
+    {
+      domain = "rewrites.example.com"
+      answer = "1.2.3.4"
+    },
+    {
+      domain = "bücher.rewrites.example.org"
+      answer = "rewrites.example.com"
+    },
+  ]
+  exclusive = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_rewrites.test", "exclusive", "true"),
+					resource.TestCheckResourceAttr("adguard_rewrites.test", "rewrites.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("adguard_rewrites.test", "rewrites.*", map[string]string{
+						"domain":  "rewrites.example.com",
+						"answer":  "1.2.3.4",
+						"enabled": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("adguard_rewrites.test", "rewrites.*", map[string]string{
+						"domain": "bücher.rewrites.example.org",
+						"answer": "rewrites.example.com",
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccRestoreFixtureRewrite - adds back the rewrite of the Docker fixture if it was deleted
+func testAccRestoreFixtureRewrite(_ *terraform.State) error {
+	adg, err := testAccAdguardClient()
+	if err != nil {
+		return err
+	}
+
+	fixtureRewrite := adgmodels.RewriteEntry{Domain: "example.org", Answer: "1.2.3.4", Enabled: true}
+
+	rewrites, err := adg.RewriteList()
+	if err != nil {
+		return err
+	}
+	for _, rewrite := range *rewrites {
+		if rewrite.Domain == fixtureRewrite.Domain && rewrite.Answer == fixtureRewrite.Answer {
+			return nil
+		}
+	}
+
+	return adg.RewriteAdd(fixtureRewrite)
+}
@@ -0,0 +1,193 @@
+package adguard
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gmichels/adguard-client-go"
+	adgmodels "github.com/gmichels/adguard-client-go/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// regex to match a file path
+var tlsFilePathIdentifier = regexp.MustCompile(`^/\w|\w:`)
+
+// isTlsFilePath - returns whether a certificate chain or private key value is a file path on the AdGuard Home server
+func isTlsFilePath(value string) bool {
+	return len(value) > 1 && tlsFilePathIdentifier.MatchString(value[0:2])
+}
+
+// setTlsCertificateAndKey - sets the certificate chain and private key of a TLS config, either as file paths or inline
+func setTlsCertificateAndKey(tlsConfig *adgmodels.TlsConfig, certificateChain string, privateKey string) {
+	if isTlsFilePath(certificateChain) {
+		tlsConfig.CertificatePath = certificateChain
+	} else {
+		tlsConfig.CertificateChain = certificateChain
+	}
+
+	if isTlsFilePath(privateKey) {
+		tlsConfig.PrivateKeyPath = privateKey
+	} else {
+		tlsConfig.PrivateKey = privateKey
+	}
+}
+
+// decodeTlsPem - decodes a base64 encoded PEM value, also accepting plain PEM
+func decodeTlsPem(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("value is not a valid base64 encoded string: %w", err)
+	}
+	return decoded, nil
+}
+
+// parseTlsCertificateChain - parses all certificates in a PEM encoded chain
+func parseTlsCertificateChain(chainPem []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, chainPem = pem.Decode(chainPem)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("certificate %d in the chain cannot be parsed: %w", len(certs), err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return certs, nil
+}
+
+// validateTlsConfig - validates the TLS config locally and, if requested, with the AdGuard Home server
+func validateTlsConfig(adg *adguard.ADG, tlsConfig adgmodels.TlsConfig, expiryWarningDays int64, validateWithServer bool,
+	certificatePath path.Path, privateKeyPath path.Path, diags *diag.Diagnostics) {
+	// nothing to validate when encryption is disabled
+	if !tlsConfig.Enabled {
+		return
+	}
+
+	// local validation is only possible when the certificate chain is provided inline
+	if tlsConfig.CertificateChain != "" {
+		validateTlsConfigLocally(tlsConfig, expiryWarningDays, certificatePath, privateKeyPath, diags)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	if !validateWithServer || adg == nil {
+		return
+	}
+
+	tlsStatus, err := adg.TlsValidate(tlsConfig)
+	if err != nil {
+		diags.AddError(
+			"Unable to Validate AdGuard Home TLS Config",
+			err.Error(),
+		)
+		return
+	}
+
+	if !tlsStatus.ValidCert || !tlsStatus.ValidKey || !tlsStatus.ValidPair {
+		diags.AddAttributeError(
+			certificatePath,
+			"TLS Config Invalid",
+			"AdGuard Home rejected the certificate chain and private key: "+tlsStatus.WarningValidation,
+		)
+	} else if tlsStatus.WarningValidation != "" {
+		diags.AddAttributeWarning(
+			certificatePath,
+			"TLS Config Warning",
+			"AdGuard Home reported a validation warning: "+tlsStatus.WarningValidation,
+		)
+	}
+}
+
+// validateTlsConfigLocally - parses the inline certificate chain and private key, checking the key matches the leaf
+// certificate, the chain is ordered from the leaf up, the server name is covered and the expiry is not too close
+func validateTlsConfigLocally(tlsConfig adgmodels.TlsConfig, expiryWarningDays int64,
+	certificatePath path.Path, privateKeyPath path.Path, diags *diag.Diagnostics) {
+	chainPem, err := decodeTlsPem(tlsConfig.CertificateChain)
+	if err != nil {
+		diags.AddAttributeError(certificatePath, "TLS Config Invalid", "Certificate chain "+err.Error())
+		return
+	}
+
+	certs, err := parseTlsCertificateChain(chainPem)
+	if err != nil {
+		diags.AddAttributeError(certificatePath, "TLS Config Invalid", "Certificate chain is invalid: "+err.Error())
+		return
+	}
+	leaf := certs[0]
+
+	// each certificate must be issued by the next one in the chain
+	for i := 0; i < len(certs)-1; i++ {
+		if err := certs[i].CheckSignatureFrom(certs[i+1]); err != nil {
+			diags.AddAttributeError(
+				certificatePath,
+				"TLS Config Invalid",
+				fmt.Sprintf("Certificate %d (%s) in the chain is not issued by certificate %d (%s). "+
+					"The chain must start with the leaf certificate, followed by each issuer in order",
+					i, certs[i].Subject, i+1, certs[i+1].Subject),
+			)
+			return
+		}
+	}
+
+	if tlsConfig.ServerName != "" {
+		if err := leaf.VerifyHostname(tlsConfig.ServerName); err != nil {
+			diags.AddAttributeError(
+				certificatePath,
+				"TLS Config Invalid",
+				fmt.Sprintf("The server name %q is not covered by the leaf certificate: %s", tlsConfig.ServerName, err.Error()),
+			)
+		}
+	}
+
+	if tlsConfig.PrivateKey != "" {
+		keyPem, err := decodeTlsPem(tlsConfig.PrivateKey)
+		if err != nil {
+			diags.AddAttributeError(privateKeyPath, "TLS Config Invalid", "Private key "+err.Error())
+			return
+		}
+		if _, err := tls.X509KeyPair(chainPem, keyPem); err != nil {
+			diags.AddAttributeError(
+				privateKeyPath,
+				"TLS Config Invalid",
+				"The private key does not match the leaf certificate: "+err.Error(),
+			)
+		}
+	}
+
+	if time.Now().After(leaf.NotAfter) {
+		diags.AddAttributeWarning(
+			certificatePath,
+			"TLS Certificate Expired",
+			fmt.Sprintf("The leaf certificate expired on %s", leaf.NotAfter.Format(time.RFC3339)),
+		)
+	} else if expiryWarningDays > 0 && time.Until(leaf.NotAfter) < time.Duration(expiryWarningDays)*24*time.Hour {
+		diags.AddAttributeWarning(
+			certificatePath,
+			"TLS Certificate Expiring Soon",
+			fmt.Sprintf("The leaf certificate expires on %s, within %d days", leaf.NotAfter.Format(time.RFC3339), expiryWarningDays),
+		)
+	}
+}
@@ -0,0 +1,599 @@
+package adguard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gmichels/adguard-client-go"
+	adgmodels "github.com/gmichels/adguard-client-go/models"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                     = &tlsResource{}
+	_ resource.ResourceWithConfigure        = &tlsResource{}
+	_ resource.ResourceWithImportState      = &tlsResource{}
+	_ resource.ResourceWithConfigValidators = &tlsResource{}
+	_ resource.ResourceWithModifyPlan       = &tlsResource{}
+)
+
+// tlsResource is the resource implementation
+type tlsResource struct {
+	adg *adguard.ADG
+}
+
+// tlsResourceModel maps TLS schema data
+type tlsResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	LastUpdated        types.String `tfsdk:"last_updated"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	ServerName         types.String `tfsdk:"server_name"`
+	ForceHttps         types.Bool   `tfsdk:"force_https"`
+	PortHttps          types.Int64  `tfsdk:"port_https"`
+	PortDnsOverTls     types.Int64  `tfsdk:"port_dns_over_tls"`
+	PortDnsOverQuic    types.Int64  `tfsdk:"port_dns_over_quic"`
+	CertificateChain   types.String `tfsdk:"certificate_chain"`
+	CertificatePath    types.String `tfsdk:"certificate_path"`
+	PrivateKey         types.String `tfsdk:"private_key"`
+	PrivateKeyPath     types.String `tfsdk:"private_key_path"`
+	ServePlainDns      types.Bool   `tfsdk:"serve_plain_dns"`
+	PrivateKeySaved    types.Bool   `tfsdk:"private_key_saved"`
+	ValidCert          types.Bool   `tfsdk:"valid_cert"`
+	ValidChain         types.Bool   `tfsdk:"valid_chain"`
+	ValidKey           types.Bool   `tfsdk:"valid_key"`
+	ValidPair          types.Bool   `tfsdk:"valid_pair"`
+	KeyType            types.String `tfsdk:"key_type"`
+	Subject            types.String `tfsdk:"subject"`
+	Issuer             types.String `tfsdk:"issuer"`
+	NotBefore          types.String `tfsdk:"not_before"`
+	NotAfter           types.String `tfsdk:"not_after"`
+	DnsNames           types.List   `tfsdk:"dns_names"`
+	WarningValidation  types.String `tfsdk:"warning_validation"`
+	ExpiryWarningDays  types.Int64  `tfsdk:"expiry_warning_days"`
+	ValidateWithServer types.Bool   `tfsdk:"validate_with_server"`
+}
+
+// NewTlsResource is a helper function to simplify the provider implementation
+func NewTlsResource() resource.Resource {
+	return &tlsResource{}
+}
+
+// Metadata returns the resource type name
+func (r *tlsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tls"
+}
+
+// Schema defines the schema for the resource
+func (r *tlsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal identifier for this TLS config",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the TLS config",
+				Computed:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether encryption (DoT/DoH/HTTPS) is enabled",
+				Required:    true,
+			},
+			"server_name": schema.StringAttribute{
+				Description: "The hostname of the TLS/HTTPS server",
+				Required:    true,
+			},
+			"force_https": schema.BoolAttribute{
+				Description: fmt.Sprintf("When `true`, forces HTTP-to-HTTPS redirect. Defaults to `%t`", CONFIG_TLS_FORCE_HTTPS),
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(CONFIG_TLS_FORCE_HTTPS),
+			},
+			"port_https": schema.Int64Attribute{
+				Description: fmt.Sprintf("The HTTPS port. Set to `0` to disable. Defaults to `%d`", CONFIG_TLS_PORT_HTTPS),
+				Computed:    true,
+				Optional:    true,
+				Default:     int64default.StaticInt64(CONFIG_TLS_PORT_HTTPS),
+				Validators: []validator.Int64{
+					checkTlsPorts(),
+				},
+			},
+			"port_dns_over_tls": schema.Int64Attribute{
+				Description: fmt.Sprintf("The DNS-over-TLS (DoT) port. Set to `0` to disable. Defaults to `%d`", CONFIG_TLS_PORT_DNS_OVER_TLS),
+				Computed:    true,
+				Optional:    true,
+				Default:     int64default.StaticInt64(CONFIG_TLS_PORT_DNS_OVER_TLS),
+			},
+			"port_dns_over_quic": schema.Int64Attribute{
+				Description: fmt.Sprintf("The DNS-over-Quic (DoQ) port. Set to `0` to disable. Defaults to `%d`", CONFIG_TLS_PORT_DNS_OVER_QUIC),
+				Computed:    true,
+				Optional:    true,
+				Default:     int64default.StaticInt64(CONFIG_TLS_PORT_DNS_OVER_QUIC),
+			},
+			"certificate_chain": schema.StringAttribute{
+				Description: "The certificates chain, as a base64 encoded string in PEM format. Conflicts with `certificate_path`. One of them is required when `enabled` is `true`",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("certificate_path")),
+				},
+			},
+			"certificate_path": schema.StringAttribute{
+				Description: "The path to the certificates chain file on the AdGuard Home server. Conflicts with `certificate_chain`. One of them is required when `enabled` is `true`",
+				Optional:    true,
+			},
+			"private_key": schema.StringAttribute{
+				Description: "The private key, as a base64 encoded string in PEM format. Conflicts with `private_key_path`. One of them is required when `enabled` is `true`",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("private_key_path")),
+				},
+			},
+			"private_key_path": schema.StringAttribute{
+				Description: "The path to the private key file on the AdGuard Home server. Conflicts with `private_key`. One of them is required when `enabled` is `true`",
+				Optional:    true,
+			},
+			"serve_plain_dns": schema.BoolAttribute{
+				Description: fmt.Sprintf("When `true`, plain DNS is allowed for incoming requests. Defaults to `%t`", CONFIG_TLS_SERVE_PLAIN_DNS),
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(CONFIG_TLS_SERVE_PLAIN_DNS),
+				Validators: []validator.Bool{
+					checkDnsEncryption(),
+				},
+			},
+			"private_key_saved": schema.BoolAttribute{
+				Description: "Whether the user has previously saved a private key",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"valid_cert": schema.BoolAttribute{
+				Description: "Whether the specified certificates chain is a valid chain of X.509 certificates",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"valid_chain": schema.BoolAttribute{
+				Description: "Whether the specified certificates chain is verified and issued by a known CA",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"valid_key": schema.BoolAttribute{
+				Description: "Whether the private key is valid",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"valid_pair": schema.BoolAttribute{
+				Description: "Whether both certificate and private key are correct",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_type": schema.StringAttribute{
+				Description: "The private key type, either `RSA` or `ECDSA`",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subject": schema.StringAttribute{
+				Description: "The subject of the first certificate in the chain",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"issuer": schema.StringAttribute{
+				Description: "The issuer of the first certificate in the chain",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"not_before": schema.StringAttribute{
+				Description: "The NotBefore field of the first certificate in the chain",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"not_after": schema.StringAttribute{
+				Description: "The NotAfter field of the first certificate in the chain",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dns_names": schema.ListAttribute{
+				Description: "The value of SubjectAltNames field of the first certificate in the chain",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"warning_validation": schema.StringAttribute{
+				Description: "The validation warning message with the issue description",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expiry_warning_days": schema.Int64Attribute{
+				Description: fmt.Sprintf("Number of days before the expiry of an inline certificate from which a warning is issued during plan. Set to `0` to disable. Defaults to `%d`", CONFIG_TLS_EXPIRY_WARNING_DAYS),
+				Computed:    true,
+				Optional:    true,
+				Default:     int64default.StaticInt64(CONFIG_TLS_EXPIRY_WARNING_DAYS),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"validate_with_server": schema.BoolAttribute{
+				Description: fmt.Sprintf("When `true`, the certificate chain and private key are validated by AdGuard Home during plan whenever they or the server name change, without being applied. Defaults to `%t`", CONFIG_TLS_VALIDATE_WITH_SERVER),
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(CONFIG_TLS_VALIDATE_WITH_SERVER),
+			},
+		},
+	}
+}
+
+// ConfigValidators validates values that depend on multiple attributes
+func (r *tlsResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		checkTlsConfig(),
+	}
+}
+
+// ModifyPlan allows for validating plan values with dynamic options
+func (r *tlsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// if plan is null, then there is no plan to work with
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// retrieve plan
+	var plan tlsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the certificate details only change along with the certificate, key or server name
+	tlsChanged := true
+	if !req.State.Raw.IsNull() {
+		var state tlsResourceModel
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tlsChanged = !plan.Enabled.Equal(state.Enabled) ||
+			!plan.ServerName.Equal(state.ServerName) ||
+			!plan.CertificateChain.Equal(state.CertificateChain) ||
+			!plan.CertificatePath.Equal(state.CertificatePath) ||
+			!plan.PrivateKey.Equal(state.PrivateKey) ||
+			!plan.PrivateKeyPath.Equal(state.PrivateKeyPath)
+	}
+	if tlsChanged && !req.State.Raw.IsNull() {
+		// the state values kept by the plan modifiers are stale, so let AdGuard Home compute them again
+		for _, name := range []string{"private_key_saved", "valid_cert", "valid_chain", "valid_key", "valid_pair"} {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), types.BoolUnknown())...)
+		}
+		for _, name := range []string{"key_type", "subject", "issuer", "not_before", "not_after", "warning_validation"} {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), types.StringUnknown())...)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("dns_names"), types.ListUnknown(types.StringType))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// values only known after apply cannot be validated
+	for _, value := range []types.String{plan.ServerName, plan.CertificateChain, plan.CertificatePath, plan.PrivateKey, plan.PrivateKeyPath} {
+		if value.IsUnknown() {
+			return
+		}
+	}
+
+	var tlsConfig adgmodels.TlsConfig
+	tlsConfig.Enabled = plan.Enabled.ValueBool()
+	tlsConfig.ServerName = plan.ServerName.ValueString()
+	tlsConfig.CertificateChain = plan.CertificateChain.ValueString()
+	tlsConfig.CertificatePath = plan.CertificatePath.ValueString()
+	tlsConfig.PrivateKey = plan.PrivateKey.ValueString()
+	tlsConfig.PrivateKeyPath = plan.PrivateKeyPath.ValueString()
+
+	// only ask AdGuard Home to validate when the certificate, key or server name change
+	validateWithServer := plan.ValidateWithServer.ValueBool() && tlsChanged
+
+	// validate the certificate chain and private key
+	validateTlsConfig(r.adg, tlsConfig, plan.ExpiryWarningDays.ValueInt64(), validateWithServer,
+		path.Root("certificate_chain"), path.Root("private_key"), &resp.Diagnostics)
+}
+
+// Configure adds the provider configured client to the resource
+func (r *tlsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.adg = req.ProviderData.(*adguard.ADG)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *tlsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tlsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// defer to common function to create or update the resource
+	r.CreateOrUpdate(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// there can be only one TLS config, so hardcode the ID as 1
+	plan.ID = types.StringValue("1")
+	// add the last updated attribute
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *tlsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get current state
+	var state tlsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get refreshed TLS config from AdGuard Home
+	tlsConfig, err := r.adg.TlsStatus()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading AdGuard Home TLS Config",
+			"Could not read AdGuard Home TLS config: "+err.Error(),
+		)
+		return
+	}
+	// convert to JSON for response logging
+	tlsConfigJson, err := json.Marshal(tlsConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse AdGuard Home TLS Config",
+			err.Error(),
+		)
+		return
+	}
+	// log response body
+	tflog.Debug(ctx, "ADG API response", map[string]interface{}{
+		"object": "tlsConfig",
+		"body":   string(tlsConfigJson),
+	})
+
+	// overwrite TLS config with refreshed state
+	state.Enabled = types.BoolValue(tlsConfig.Enabled)
+	state.ServerName = types.StringValue(tlsConfig.ServerName)
+	state.ForceHttps = types.BoolValue(tlsConfig.ForceHttps)
+	state.PortHttps = types.Int64Value(int64(tlsConfig.PortHttps))
+	state.PortDnsOverTls = types.Int64Value(int64(tlsConfig.PortDnsOverTls))
+	state.PortDnsOverQuic = types.Int64Value(int64(tlsConfig.PortDnsOverQuic))
+	state.ServePlainDns = types.BoolValue(tlsConfig.ServePlainDns)
+	// the certificate chain is either provided inline or as a file path
+	if tlsConfig.CertificatePath != "" {
+		state.CertificatePath = types.StringValue(tlsConfig.CertificatePath)
+		state.CertificateChain = types.StringNull()
+	} else {
+		state.CertificatePath = types.StringNull()
+		if tlsConfig.CertificateChain != "" {
+			state.CertificateChain = types.StringValue(tlsConfig.CertificateChain)
+		} else {
+			state.CertificateChain = types.StringNull()
+		}
+	}
+	// the private key is either provided inline or as a file path
+	if tlsConfig.PrivateKeyPath != "" {
+		state.PrivateKeyPath = types.StringValue(tlsConfig.PrivateKeyPath)
+		state.PrivateKey = types.StringNull()
+	} else {
+		state.PrivateKeyPath = types.StringNull()
+		// AdGuard Home never returns a saved private key, so keep the one in state
+		if tlsConfig.PrivateKey != "" {
+			state.PrivateKey = types.StringValue(tlsConfig.PrivateKey)
+		}
+	}
+
+	// the validation settings are null after an import
+	if state.ExpiryWarningDays.IsNull() {
+		state.ExpiryWarningDays = types.Int64Value(CONFIG_TLS_EXPIRY_WARNING_DAYS)
+	}
+	if state.ValidateWithServer.IsNull() {
+		state.ValidateWithServer = types.BoolValue(CONFIG_TLS_VALIDATE_WITH_SERVER)
+	}
+
+	// map the computed attributes
+	mapTlsComputedAttributes(ctx, &state, tlsConfig, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *tlsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tlsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// defer to common function to create or update the resource
+	r.CreateOrUpdate(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update resource state with updated items and timestamp
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// update state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *tlsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// there is no "real" delete for the TLS config, so this means "restore defaults"
+
+	// instantiate empty tls config for storing default values
+	var tlsConfig adgmodels.TlsConfig
+
+	// populate tls config list with default values
+	tlsConfig.Enabled = CONFIG_TLS_ENABLED
+	tlsConfig.ServerName = ""
+	tlsConfig.ForceHttps = CONFIG_TLS_FORCE_HTTPS
+	tlsConfig.PortHttps = CONFIG_TLS_PORT_HTTPS
+	tlsConfig.PortDnsOverTls = CONFIG_TLS_PORT_DNS_OVER_TLS
+	tlsConfig.PortDnsOverQuic = CONFIG_TLS_PORT_DNS_OVER_QUIC
+	tlsConfig.CertificateChain = ""
+	tlsConfig.PrivateKey = ""
+	tlsConfig.CertificatePath = ""
+	tlsConfig.PrivateKeyPath = ""
+	// plain DNS is required in case encryption protocols are disabled
+	tlsConfig.ServePlainDns = true
+
+	// set tls config to defaults
+	_, err := r.adg.TlsConfigure(tlsConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting AdGuard Home TLS Config",
+			"Could not delete TLS config, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *tlsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// CreateOrUpdate - common function to create or update the TLS config
+func (r *tlsResource) CreateOrUpdate(ctx context.Context, plan *tlsResourceModel, diags *diag.Diagnostics) {
+	// instantiate empty object for storing plan data
+	var tlsConfig adgmodels.TlsConfig
+	// populate tls config from plan
+	tlsConfig.Enabled = plan.Enabled.ValueBool()
+	tlsConfig.ServerName = plan.ServerName.ValueString()
+	tlsConfig.ForceHttps = plan.ForceHttps.ValueBool()
+	tlsConfig.PortHttps = uint16(plan.PortHttps.ValueInt64())
+	tlsConfig.PortDnsOverTls = uint16(plan.PortDnsOverTls.ValueInt64())
+	tlsConfig.PortDnsOverQuic = uint16(plan.PortDnsOverQuic.ValueInt64())
+	tlsConfig.ServePlainDns = plan.ServePlainDns.ValueBool()
+	tlsConfig.CertificateChain = plan.CertificateChain.ValueString()
+	tlsConfig.CertificatePath = plan.CertificatePath.ValueString()
+	tlsConfig.PrivateKey = plan.PrivateKey.ValueString()
+	tlsConfig.PrivateKeyPath = plan.PrivateKeyPath.ValueString()
+
+	// set tls config using plan
+	tlsConfigResponse, err := r.adg.TlsConfigure(tlsConfig)
+	if err != nil {
+		diags.AddError(
+			"Unable to Update AdGuard Home TLS Config",
+			err.Error(),
+		)
+		return
+	}
+
+	// populate computed attributes
+	mapTlsComputedAttributes(ctx, plan, tlsConfigResponse, diags)
+}
+
+// mapTlsComputedAttributes - maps the computed TLS attributes from an API response
+func mapTlsComputedAttributes(ctx context.Context, o *tlsResourceModel, tlsConfig *adgmodels.TlsConfig, diags *diag.Diagnostics) {
+	o.PrivateKeySaved = types.BoolValue(tlsConfig.PrivateKeySaved)
+	o.ValidCert = types.BoolValue(tlsConfig.ValidCert)
+	o.ValidChain = types.BoolValue(tlsConfig.ValidChain)
+	o.ValidKey = types.BoolValue(tlsConfig.ValidKey)
+	o.ValidPair = types.BoolValue(tlsConfig.ValidPair)
+	o.KeyType = types.StringValue(tlsConfig.KeyType)
+	o.Subject = types.StringValue(tlsConfig.Subject)
+	o.Issuer = types.StringValue(tlsConfig.Issuer)
+	// handle default timestamp from upstream
+	if tlsConfig.NotBefore != "0001-01-01T00:00:00Z" {
+		o.NotBefore = types.StringValue(tlsConfig.NotBefore)
+	} else {
+		o.NotBefore = types.StringValue("")
+	}
+	// handle default timestamp from upstream
+	if tlsConfig.NotAfter != "0001-01-01T00:00:00Z" {
+		o.NotAfter = types.StringValue(tlsConfig.NotAfter)
+	} else {
+		o.NotAfter = types.StringValue("")
+	}
+	if len(tlsConfig.DnsNames) > 0 {
+		var d diag.Diagnostics
+		o.DnsNames, d = types.ListValueFrom(ctx, types.StringType, tlsConfig.DnsNames)
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+	} else {
+		o.DnsNames = types.ListValueMust(types.StringType, []attr.Value{})
+	}
+	o.WarningValidation = types.StringValue(tlsConfig.WarningValidation)
+}
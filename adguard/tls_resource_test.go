@@ -0,0 +1,126 @@
+package adguard
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTlsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Port conflict validation testing
+			{
+				Config: providerConfig + `
+resource "adguard_tls" "test" {
+  enabled           = true
+  server_name       = "Test AdGuard Home"
+  certificate_path  = "/opt/adguardhome/ssl/server.crt"
+  private_key_path  = "/opt/adguardhome/ssl/server.key"
+  port_https        = 853
+}
+`,
+				ExpectError: regexp.MustCompile("TLS Ports Config Invalid"),
+			},
+			// Certificate validation testing
+			{
+				Config: providerConfig + `
+resource "adguard_tls" "test" {
+  enabled           = true
+  server_name       = "Test AdGuard Home"
+  certificate_chain = base64encode("not a certificate")
+  private_key_path  = "/opt/adguardhome/ssl/server.key"
+}
+`,
+				ExpectError: regexp.MustCompile("TLS Config Invalid"),
+			},
+			// Missing private key validation testing
+			{
+				Config: providerConfig + `
+resource "adguard_tls" "test" {
+  enabled          = true
+  server_name      = "Test AdGuard Home"
+  certificate_path = "/opt/adguardhome/ssl/server.crt"
+}
+`,
+				ExpectError: regexp.MustCompile(`One of "private_key" or "private_key_path" must be specified`),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "adguard_tls" "test" {
+  enabled          = true
+  server_name      = "Test AdGuard Home"
+  certificate_path = "/opt/adguardhome/ssl/server.crt"
+  private_key_path = "/opt/adguardhome/ssl/server.key"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_tls.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "server_name", "Test AdGuard Home"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "certificate_path", "/opt/adguardhome/ssl/server.crt"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "private_key_path", "/opt/adguardhome/ssl/server.key"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "port_https", "443"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "serve_plain_dns", "true"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "valid_pair", "true"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "issuer", "CN=TestRootCA,O=AdGuard Home,L=Dallas,ST=Texas,C=US"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "id", "1"),
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("adguard_tls.test", "not_after"),
+					resource.TestCheckResourceAttrSet("adguard_tls.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "adguard_tls.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The last_updated attribute does not exist in AdGuard Home,
+				// therefore there is no value for it during import
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "adguard_tls" "test" {
+  enabled              = true
+  server_name          = "Test AdGuard Home"
+  certificate_path     = "/opt/adguardhome/ssl/server.crt"
+  private_key_path     = "/opt/adguardhome/ssl/server.key"
+  port_https           = 4443
+  port_dns_over_quic   = 8853
+  serve_plain_dns      = false
+  expiry_warning_days  = 10
+  validate_with_server = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_tls.test", "expiry_warning_days", "10"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "validate_with_server", "true"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "port_https", "4443"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "port_dns_over_tls", "853"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "port_dns_over_quic", "8853"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "serve_plain_dns", "false"),
+					resource.TestCheckResourceAttr("adguard_tls.test", "valid_pair", "true"),
+				),
+			},
+			// Update to disabled without certificate and private key testing
+			{
+				Config: providerConfig + `
+resource "adguard_tls" "test" {
+  enabled     = false
+  server_name = "Test AdGuard Home"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_tls.test", "enabled", "false"),
+					resource.TestCheckNoResourceAttr("adguard_tls.test", "certificate_path"),
+					resource.TestCheckNoResourceAttr("adguard_tls.test", "private_key_path"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,124 @@
+package adguard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/net/idna"
+)
+
+// ensure the implementation satisfies the expected interfaces
+var (
+	_ basetypes.StringTypable                    = domainType{}
+	_ basetypes.StringValuableWithSemanticEquals = domainValue{}
+)
+
+// IDNA profile used to convert domains to their ASCII form, allowing underscores as used in service records
+var domainIdnaProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.StrictDomainName(false),
+)
+
+// domainType is a string type for domain names, whose values are equal when they represent the same
+// domain in either Unicode (IDN) or punycode spelling
+type domainType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name
+func (t domainType) String() string {
+	return "domainType"
+}
+
+// Equal returns true if the given type is equivalent
+func (t domainType) Equal(o attr.Type) bool {
+	_, ok := o.(domainType)
+	return ok
+}
+
+// ValueType returns the value type of this type
+func (t domainType) ValueType(_ context.Context) attr.Value {
+	return domainValue{}
+}
+
+// ValueFromString converts a string value into a domain value
+func (t domainType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return domainValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform converts a Terraform value into a domain value
+func (t domainType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return domainValue{StringValue: stringValue}, nil
+}
+
+// domainValue is the value of a domainType
+type domainValue struct {
+	basetypes.StringValue
+}
+
+// newDomainValue - returns a known domain value
+func newDomainValue(value string) domainValue {
+	return domainValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// Type returns the type of this value
+func (v domainValue) Type(_ context.Context) attr.Type {
+	return domainType{}
+}
+
+// Equal returns true if the given value is exactly equal, including its spelling
+func (v domainValue) Equal(o attr.Value) bool {
+	other, ok := o.(domainValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if the given value represents the same domain
+func (v domainValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(domainValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return normalizeDomain(v.ValueString()) == normalizeDomain(newValue.ValueString()), diags
+}
+
+// normalizeDomain - returns the lowercase punycode spelling of a domain, keeping a leading wildcard label,
+// or the lowercase domain as is when it cannot be converted
+func normalizeDomain(domain string) string {
+	name, wildcard := strings.CutPrefix(domain, "*.")
+
+	ascii, err := domainIdnaProfile.ToASCII(name)
+	if err != nil {
+		return strings.ToLower(domain)
+	}
+
+	if wildcard {
+		return "*." + strings.ToLower(ascii)
+	}
+	return strings.ToLower(ascii)
+}
@@ -0,0 +1,145 @@
+package adguard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ensure the implementation satisfies the expected interfaces
+var (
+	_ basetypes.StringTypable                    = networkAddressType{}
+	_ basetypes.StringValuableWithSemanticEquals = networkAddressValue{}
+)
+
+// networkAddressKind identifies how a network address is normalized for comparison
+type networkAddressKind string
+
+const (
+	networkAddressMac      networkAddressKind = "mac"
+	networkAddressIp       networkAddressKind = "ip"
+	networkAddressClientId networkAddressKind = "client_id"
+)
+
+// predefined types for each kind of network address
+var (
+	macAddressType = networkAddressType{kind: networkAddressMac}
+	ipAddressType  = networkAddressType{kind: networkAddressIp}
+	clientIdType   = networkAddressType{kind: networkAddressClientId}
+)
+
+// networkAddressType is a string type for MAC addresses, IP addresses and client IDs (IP, CIDR, MAC or ClientID)
+// whose values are equal when they represent the same address, regardless of how they are spelled
+type networkAddressType struct {
+	basetypes.StringType
+	kind networkAddressKind
+}
+
+// String returns a human readable string of the type name
+func (t networkAddressType) String() string {
+	return fmt.Sprintf("networkAddressType[%s]", t.kind)
+}
+
+// Equal returns true if the given type is equivalent
+func (t networkAddressType) Equal(o attr.Type) bool {
+	other, ok := o.(networkAddressType)
+	if !ok {
+		return false
+	}
+	return t.kind == other.kind
+}
+
+// ValueType returns the value type of this type
+func (t networkAddressType) ValueType(_ context.Context) attr.Value {
+	return networkAddressValue{kind: t.kind}
+}
+
+// ValueFromString converts a string value into a network address value
+func (t networkAddressType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return networkAddressValue{StringValue: in, kind: t.kind}, nil
+}
+
+// ValueFromTerraform converts a Terraform value into a network address value
+func (t networkAddressType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return networkAddressValue{StringValue: stringValue, kind: t.kind}, nil
+}
+
+// networkAddressValue is the value of a networkAddressType
+type networkAddressValue struct {
+	basetypes.StringValue
+	kind networkAddressKind
+}
+
+// newNetworkAddressValue - returns a known network address value
+func newNetworkAddressValue(t networkAddressType, value string) networkAddressValue {
+	return networkAddressValue{StringValue: basetypes.NewStringValue(value), kind: t.kind}
+}
+
+// newNetworkAddressNull - returns a null network address value
+func newNetworkAddressNull(t networkAddressType) networkAddressValue {
+	return networkAddressValue{StringValue: basetypes.NewStringNull(), kind: t.kind}
+}
+
+// Type returns the type of this value
+func (v networkAddressValue) Type(_ context.Context) attr.Type {
+	return networkAddressType{kind: v.kind}
+}
+
+// Equal returns true if the given value is exactly equal, including its spelling
+func (v networkAddressValue) Equal(o attr.Value) bool {
+	other, ok := o.(networkAddressValue)
+	if !ok {
+		return false
+	}
+	return v.kind == other.kind && v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if the given value represents the same network address
+func (v networkAddressValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(networkAddressValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return normalizeNetworkAddress(v.kind, v.ValueString()) == normalizeNetworkAddress(newValue.kind, newValue.ValueString()), diags
+}
+
+// normalizeNetworkAddress - returns the canonical spelling of a network address, or the
+// address as is when it cannot be parsed
+func normalizeNetworkAddress(kind networkAddressKind, value string) string {
+	switch kind {
+	case networkAddressMac:
+		if mac, err := net.ParseMAC(value); err == nil {
+			return mac.String()
+		}
+	case networkAddressIp:
+		if addr, err := netip.ParseAddr(value); err == nil {
+			return addr.Unmap().String()
+		}
+	case networkAddressClientId:
+		return normalizeClientId(value)
+	}
+	return value
+}
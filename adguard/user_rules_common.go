@@ -0,0 +1,422 @@
+package adguard
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// the user rules can only be written as a whole, so ensure read-modify-write operations are serialized
+var userRulesMutex sync.Mutex
+
+// prefix of the ID of a resource managing a user rules section, followed by the section name
+const userRulesSectionIdPrefix = "section:"
+
+// prefixes of the comment lines delimiting a user rules section, followed by the section name
+const (
+	userRulesSectionBeginPrefix = "! terraform:begin "
+	userRulesSectionEndPrefix   = "! terraform:end "
+)
+
+// getUserRulesSectionMarkers - Return the comment lines delimiting a user rules section
+func getUserRulesSectionMarkers(section string) (string, string) {
+	return userRulesSectionBeginPrefix + section, userRulesSectionEndPrefix + section
+}
+
+// findUserRulesSection - Return the indexes of the begin and end markers of a user rules section, or -1 if not found
+func findUserRulesSection(rules []string, section string) (int, int) {
+	beginMarker, endMarker := getUserRulesSectionMarkers(section)
+
+	begin := -1
+	for i, rule := range rules {
+		if rule == beginMarker && begin == -1 {
+			begin = i
+		} else if rule == endMarker && begin != -1 {
+			return begin, i
+		}
+	}
+
+	return -1, -1
+}
+
+// getUserRulesSection - Return the rules within a user rules section and whether the section exists
+func getUserRulesSection(rules []string, section string) ([]string, bool) {
+	begin, end := findUserRulesSection(rules, section)
+	if begin == -1 {
+		return nil, false
+	}
+
+	sectionRules := []string{}
+	sectionRules = append(sectionRules, rules[begin+1:end]...)
+	return sectionRules, true
+}
+
+// setUserRulesSection - Return the user rules with a section replaced, or appended if it does not exist yet
+func setUserRulesSection(rules []string, section string, sectionRules []string) []string {
+	beginMarker, endMarker := getUserRulesSectionMarkers(section)
+
+	var newRules []string
+	begin, end := findUserRulesSection(rules, section)
+	if begin == -1 {
+		newRules = append(newRules, rules...)
+		newRules = append(newRules, beginMarker)
+		newRules = append(newRules, sectionRules...)
+		return append(newRules, endMarker)
+	}
+
+	newRules = append(newRules, rules[:begin+1]...)
+	newRules = append(newRules, sectionRules...)
+	return append(newRules, rules[end:]...)
+}
+
+// removeUserRulesSection - Return the user rules without a section, including its markers
+func removeUserRulesSection(rules []string, section string) []string {
+	begin, end := findUserRulesSection(rules, section)
+	if begin == -1 {
+		return rules
+	}
+
+	var newRules []string
+	newRules = append(newRules, rules[:begin]...)
+	return append(newRules, rules[end+1:]...)
+}
+
+// splitUserRulesSections - Return the user rules outside of any section, and the lines of all sections including their markers
+func splitUserRulesSections(rules []string) ([]string, []string) {
+	var unsectionedRules, sectionLines []string
+	for i := 0; i < len(rules); i++ {
+		if section, ok := strings.CutPrefix(rules[i], userRulesSectionBeginPrefix); ok {
+			if begin, end := findUserRulesSection(rules[i:], section); begin == 0 {
+				sectionLines = append(sectionLines, rules[i:i+end+1]...)
+				i += end
+				continue
+			}
+		}
+		unsectionedRules = append(unsectionedRules, rules[i])
+	}
+
+	return unsectionedRules, sectionLines
+}
+
+// userRuleModel maps structured user rule schema data
+type userRuleModel struct {
+	Pattern   types.String `tfsdk:"pattern"`
+	Action    types.String `tfsdk:"action"`
+	Important types.Bool   `tfsdk:"important"`
+	Client    types.Set    `tfsdk:"client"`
+	Ctag      types.Set    `tfsdk:"ctag"`
+	Dnstype   types.Set    `tfsdk:"dnstype"`
+	Denyallow types.Set    `tfsdk:"denyallow"`
+	Comment   types.String `tfsdk:"comment"`
+}
+
+// attrTypes - return attribute types for this model
+func (o userRuleModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"pattern":   types.StringType,
+		"action":    types.StringType,
+		"important": types.BoolType,
+		"client":    types.SetType{ElemType: types.StringType},
+		"ctag":      types.SetType{ElemType: types.StringType},
+		"dnstype":   types.SetType{ElemType: types.StringType},
+		"denyallow": types.SetType{ElemType: types.StringType},
+		"comment":   types.StringType,
+	}
+}
+
+// userRule holds a structured user rule in AdGuard filtering syntax
+type userRule struct {
+	Pattern   string
+	Allow     bool
+	Important bool
+	Client    []string
+	Ctag      []string
+	Dnstype   []string
+	Denyallow []string
+	Comment   string
+}
+
+// values that can be used in a modifier without quoting
+var userRuleSimpleValue = regexp.MustCompile(`^~?[A-Za-z0-9._:/*-]+$`)
+
+// toUserRule - converts the schema model into a structured user rule
+func (o userRuleModel) toUserRule(ctx context.Context, diags *diag.Diagnostics) userRule {
+	rule := userRule{
+		Pattern:   o.Pattern.ValueString(),
+		Allow:     o.Action.ValueString() == "allow",
+		Important: o.Important.ValueBool(),
+		Comment:   o.Comment.ValueString(),
+	}
+
+	for _, modifier := range []struct {
+		set    types.Set
+		values *[]string
+	}{
+		{o.Client, &rule.Client},
+		{o.Ctag, &rule.Ctag},
+		{o.Dnstype, &rule.Dnstype},
+		{o.Denyallow, &rule.Denyallow},
+	} {
+		if !modifier.set.IsNull() && !modifier.set.IsUnknown() {
+			diags.Append(modifier.set.ElementsAs(ctx, modifier.values, false)...)
+			sort.Strings(*modifier.values)
+		}
+	}
+
+	return rule
+}
+
+// toModel - converts a structured user rule into the schema model
+func (o userRule) toModel(ctx context.Context, diags *diag.Diagnostics) userRuleModel {
+	var d diag.Diagnostics
+	model := userRuleModel{
+		Pattern:   types.StringValue(o.Pattern),
+		Action:    types.StringValue("block"),
+		Important: types.BoolValue(o.Important),
+		Comment:   types.StringNull(),
+	}
+	if o.Allow {
+		model.Action = types.StringValue("allow")
+	}
+	if o.Comment != "" {
+		model.Comment = types.StringValue(o.Comment)
+	}
+
+	for _, modifier := range []struct {
+		values []string
+		set    *types.Set
+	}{
+		{o.Client, &model.Client},
+		{o.Ctag, &model.Ctag},
+		{o.Dnstype, &model.Dnstype},
+		{o.Denyallow, &model.Denyallow},
+	} {
+		if len(modifier.values) > 0 {
+			*modifier.set, d = types.SetValueFrom(ctx, types.StringType, modifier.values)
+			diags.Append(d...)
+		} else {
+			*modifier.set = types.SetNull(types.StringType)
+		}
+	}
+
+	return model
+}
+
+// String - renders the user rule in AdGuard filtering syntax, without its comment
+func (o userRule) String() string {
+	var rule strings.Builder
+	if o.Allow {
+		rule.WriteString("@@")
+	}
+	rule.WriteString(o.Pattern)
+
+	var modifiers []string
+	if o.Important {
+		modifiers = append(modifiers, "important")
+	}
+	for _, modifier := range []struct {
+		name   string
+		values []string
+	}{
+		{"client", o.Client},
+		{"ctag", o.Ctag},
+		{"dnstype", o.Dnstype},
+		{"denyallow", o.Denyallow},
+	} {
+		if len(modifier.values) == 0 {
+			continue
+		}
+		var values []string
+		for _, value := range modifier.values {
+			values = append(values, quoteUserRuleValue(value))
+		}
+		modifiers = append(modifiers, modifier.name+"="+strings.Join(values, "|"))
+	}
+
+	if len(modifiers) > 0 {
+		rule.WriteString("$" + strings.Join(modifiers, ","))
+	}
+
+	return rule.String()
+}
+
+// lines - renders the user rule in AdGuard filtering syntax, preceded by its comment if any
+func (o userRule) lines() []string {
+	if o.Comment != "" {
+		return []string{"! " + o.Comment, o.String()}
+	}
+	return []string{o.String()}
+}
+
+// parseUserRule - parses a line in AdGuard filtering syntax into a structured user rule,
+// returning false when the line cannot be represented as such
+func parseUserRule(line string) (userRule, bool) {
+	var rule userRule
+	original := line
+
+	// comments, hosts syntax and regular expressions are kept as raw rules
+	if line == "" || strings.HasPrefix(line, "!") || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "/") {
+		return rule, false
+	}
+
+	if strings.HasPrefix(line, "@@") {
+		rule.Allow = true
+		line = strings.TrimPrefix(line, "@@")
+	}
+
+	pattern, modifiers, found := strings.Cut(line, "$")
+	rule.Pattern = pattern
+	if pattern == "" || strings.HasPrefix(pattern, "@") || strings.ContainsAny(pattern, " \t") {
+		return rule, false
+	}
+
+	if found {
+		for _, modifier := range splitUserRuleModifier(modifiers, ',') {
+			name, value, hasValue := strings.Cut(modifier, "=")
+			var values *[]string
+			switch name {
+			case "important":
+				if hasValue || rule.Important {
+					return rule, false
+				}
+				rule.Important = true
+				continue
+			case "client":
+				values = &rule.Client
+			case "ctag":
+				values = &rule.Ctag
+			case "dnstype":
+				values = &rule.Dnstype
+			case "denyallow":
+				values = &rule.Denyallow
+			default:
+				// any other modifier cannot be represented
+				return rule, false
+			}
+			if !hasValue || len(*values) > 0 {
+				return rule, false
+			}
+			for _, v := range splitUserRuleModifier(value, '|') {
+				*values = append(*values, unquoteUserRuleValue(v))
+			}
+		}
+	}
+
+	// only accept rules that render back exactly as they are, so no information is lost
+	if rule.String() != original {
+		return rule, false
+	}
+
+	return rule, true
+}
+
+// quoteUserRuleValue - quotes a modifier value if it contains special characters
+func quoteUserRuleValue(value string) string {
+	if userRuleSimpleValue.MatchString(value) {
+		return value
+	}
+	replacer := strings.NewReplacer(`\`, `\\`, `'`, `\'`, `"`, `\"`, `,`, `\,`, `|`, `\|`)
+	return "'" + replacer.Replace(value) + "'"
+}
+
+// unquoteUserRuleValue - removes quotes and escaping from a modifier value
+func unquoteUserRuleValue(value string) string {
+	if len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+		return value
+	}
+
+	var unquoted strings.Builder
+	escaped := false
+	for _, c := range value[1 : len(value)-1] {
+		if c == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		unquoted.WriteRune(c)
+	}
+	return unquoted.String()
+}
+
+// splitUserRuleModifier - splits on a separator that is neither quoted nor escaped
+func splitUserRuleModifier(s string, sep rune) []string {
+	var parts []string
+	var current strings.Builder
+	var quote rune
+	escaped := false
+	for _, c := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '\'' || c == '"'):
+			quote = c
+		case quote == 0 && c == sep:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(c)
+	}
+	return append(parts, current.String())
+}
+
+// renderUserRules - combines raw and structured user rules into the lines to be written,
+// with structured rules following the raw ones in the order they are declared
+func renderUserRules(rawRules []string, structuredRules []userRule) []string {
+	rules := []string{}
+	rules = append(rules, rawRules...)
+	for _, rule := range structuredRules {
+		rules = append(rules, rule.lines()...)
+	}
+	return rules
+}
+
+// splitUserRules - separates lines into raw rules, matching the ones already known as raw, and structured rules
+func splitUserRules(lines []string, knownRawRules []string) ([]string, []userRule) {
+	// track how many times each known raw rule can still be matched
+	rawCount := make(map[string]int)
+	for _, rule := range knownRawRules {
+		rawCount[rule]++
+	}
+
+	rawRules := []string{}
+	var structuredRules []userRule
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if rawCount[line] > 0 {
+			rawCount[line]--
+			rawRules = append(rawRules, line)
+			continue
+		}
+
+		// a comment immediately followed by a structured rule belongs to it
+		if strings.HasPrefix(line, "! ") && i+1 < len(lines) && rawCount[lines[i+1]] == 0 {
+			if rule, ok := parseUserRule(lines[i+1]); ok {
+				rule.Comment = strings.TrimPrefix(line, "! ")
+				structuredRules = append(structuredRules, rule)
+				i++
+				continue
+			}
+		}
+
+		if rule, ok := parseUserRule(line); ok {
+			structuredRules = append(structuredRules, rule)
+			continue
+		}
+
+		// anything else is kept as a raw rule
+		rawRules = append(rawRules, line)
+	}
+
+	return rawRules, structuredRules
+}
@@ -0,0 +1,5 @@
+package adguard
+
+// adguard_user_rules defaults
+const USER_RULE_ACTION = "block"
+const USER_RULE_IMPORTANT = false
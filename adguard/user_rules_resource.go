@@ -3,15 +3,25 @@ package adguard
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/gmichels/adguard-client-go"
 	adgmodels "github.com/gmichels/adguard-client-go/models"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -32,7 +42,10 @@ type userRulesResource struct {
 type userRulesResourceModel struct {
 	ID          types.String `tfsdk:"id"`
 	Rules       types.List   `tfsdk:"rules"`
+	Rule        types.List   `tfsdk:"rule"`
+	Section     types.String `tfsdk:"section"`
 	LastUpdated types.String `tfsdk:"last_updated"`
+	OnDestroy   types.String `tfsdk:"on_destroy"`
 }
 
 // NewUserRulesResource is a helper function to simplify the provider implementation
@@ -57,14 +70,113 @@ func (r *userRulesResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"rules": schema.ListAttribute{
-				Description: "List of user rules",
+				Description: "List of user rules, as raw strings in AdGuard filtering syntax. At least one of `rules` or `rule` must be specified",
 				ElementType: types.StringType,
-				Required:    true,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.AtLeastOneOf(path.MatchRoot("rule")),
+					checkUserRules(),
+				},
+			},
+			"rule": schema.ListNestedAttribute{
+				Description: "List of structured user rules, rendered in AdGuard filtering syntax after the raw `rules`, in the order they are declared",
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"pattern": schema.StringAttribute{
+							Description: "Pattern of the rule, for example `||example.org^`",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(
+									regexp.MustCompile(`^[^\s!#@$/][^\s$]*$`),
+									"must be a rule pattern without whitespace, modifiers, allowlist prefix or regular expression",
+								),
+								checkUserRulePattern(),
+							},
+						},
+						"action": schema.StringAttribute{
+							Description: fmt.Sprintf("Whether matching requests are blocked or allowed. Valid values are `block` and `allow`. Defaults to `%s`", USER_RULE_ACTION),
+							Computed:    true,
+							Optional:    true,
+							Default:     stringdefault.StaticString(USER_RULE_ACTION),
+							Validators: []validator.String{
+								stringvalidator.OneOf("block", "allow"),
+							},
+						},
+						"important": schema.BoolAttribute{
+							Description: fmt.Sprintf("Whether the rule takes precedence over all other rules, via the `$important` modifier. Defaults to `%t`", USER_RULE_IMPORTANT),
+							Computed:    true,
+							Optional:    true,
+							Default:     booldefault.StaticBool(USER_RULE_IMPORTANT),
+						},
+						"client": schema.SetAttribute{
+							Description: "Clients the rule applies to, via the `$client` modifier. Prefix a client with `~` to exclude it",
+							ElementType: types.StringType,
+							Optional:    true,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+						"ctag": schema.SetAttribute{
+							Description: "Client tags the rule applies to, via the `$ctag` modifier. Prefix a tag with `~` to exclude it",
+							ElementType: types.StringType,
+							Optional:    true,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+						"dnstype": schema.SetAttribute{
+							Description: "DNS record types the rule applies to, via the `$dnstype` modifier. Prefix a type with `~` to exclude it",
+							ElementType: types.StringType,
+							Optional:    true,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+						"denyallow": schema.SetAttribute{
+							Description: "Domains excluded from the rule, via the `$denyallow` modifier",
+							ElementType: types.StringType,
+							Optional:    true,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+						"comment": schema.StringAttribute{
+							Description: "Comment for the rule, rendered as a `!` line preceding it",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(
+									regexp.MustCompile(`^[^\r\n]+$`),
+									"must be a single line",
+								),
+							},
+						},
+					},
+				},
+			},
+			"section": schema.StringAttribute{
+				Description: "Name of the section of user rules managed by this resource. " +
+					"When set, only the rules between the `! terraform:begin <section>` and `! terraform:end <section>` comment lines are managed, " +
+					"leaving all other user rules alone. When not set, the entire list of user rules is managed, except for the sections managed by other resources",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`),
+						"must only contain letters, numbers, underscores, periods and hyphens",
+					),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Description: "Timestamp of the last Terraform update of the client",
 				Computed:    true,
 			},
+			"on_destroy": onDestroyResourceSchema(),
 		},
 	}
 }
@@ -88,30 +200,60 @@ func (r *userRulesResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	// instantiate empty client for storing plan data
-	var userRules adgmodels.SetRulesRequest
+	// ensure no other user rules resource changes the user rules in the meantime
+	userRulesMutex.Lock()
+	defer userRulesMutex.Unlock()
 
-	// populate user rules from plan
-	if len(plan.Rules.Elements()) > 0 {
-		diags = plan.Rules.ElementsAs(ctx, &userRules.Rules, false)
+	// retrieve the current user rules
+	allFilters, err := r.adg.FilteringStatus()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating AdGuard Home User Rules",
+			"Could not read current user rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if plan.Section.IsNull() {
+		// capture the current user rules so they can be restored on destroy. Sections are managed
+		// by their own resources, so they are left out
+		unsectionedRules, _ := splitUserRulesSections(allFilters.UserRules)
+		snapshotJson, err := json.Marshal(unsectionedRules)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Creating AdGuard Home User Rules",
+				"Could not serialize current user rules, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		diags = resp.Private.SetKey(ctx, snapshotPrivateStateKey, snapshotJson)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
+
+		// there can be only one entry for the entire user rules, so hardcode the ID as 1
+		plan.ID = types.StringValue("1")
+	} else {
+		// a section can only be managed by a single resource
+		if _, found := getUserRulesSection(allFilters.UserRules, plan.Section.ValueString()); found {
+			resp.Diagnostics.AddError(
+				"Error Creating AdGuard Home User Rules",
+				"User rules section "+plan.Section.ValueString()+" already exists",
+			)
+			return
+		}
+
+		// the section name uniquely identifies the section
+		plan.ID = types.StringValue(userRulesSectionIdPrefix + plan.Section.ValueString())
 	}
 
-	// create user rules using plan
-	err := r.adg.FilteringSetRules(userRules)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating AdGuard Home User Rules",
-			"Could not create user rules, unexpected error: "+err.Error(),
-		)
+	// defer to common function to create or update the resource
+	r.CreateOrUpdate(ctx, &plan, allFilters.UserRules, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// there can be only one entry for user rules, so hardcode the ID as 1
-	plan.ID = types.StringValue("1")
 	// add the last updated attribute
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
@@ -157,11 +299,70 @@ func (r *userRulesResource) Read(ctx context.Context, req resource.ReadRequest,
 		"body":   string(userRulesJson),
 	})
 
+	// sections are managed by their own resources, so they are not part of the entire user rules
+	userRules, _ := splitUserRulesSections(allFilters.UserRules)
+	if !state.Section.IsNull() {
+		// only the rules within the section are managed
+		var found bool
+		userRules, found = getUserRulesSection(allFilters.UserRules, state.Section.ValueString())
+		if !found {
+			resp.Diagnostics.AddWarning(
+				"AdGuard Home User Rules Section was deleted outside of Terraform",
+				"No such user rules section with id "+state.ID.ValueString(),
+			)
+			// remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	}
+
 	// overwrite user rules with refreshed state
-	state.Rules, diags = types.ListValueFrom(ctx, types.StringType, allFilters.UserRules)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	if state.Rule.IsNull() {
+		// structured rules are not in use, so all rules are raw. This is also the case after an import,
+		// as which rules the configuration holds as structured ones is not known yet
+		state.Rules, diags = types.ListValueFrom(ctx, types.StringType, userRules)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		// rules known as raw remain raw, all others are parsed into structured rules when possible
+		var stateRules []string
+		if !state.Rules.IsNull() {
+			diags = state.Rules.ElementsAs(ctx, &stateRules, false)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+		rawRules, structuredRules := splitUserRules(userRules, stateRules)
+
+		if len(rawRules) == 0 && state.Rules.IsNull() {
+			state.Rules = types.ListNull(types.StringType)
+		} else {
+			state.Rules, diags = types.ListValueFrom(ctx, types.StringType, rawRules)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		stateRuleModels := []userRuleModel{}
+		for _, rule := range structuredRules {
+			stateRuleModels = append(stateRuleModels, rule.toModel(ctx, &resp.Diagnostics))
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.Rule, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: userRuleModel{}.attrTypes()}, stateRuleModels)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	// the destroy behavior is null after an import
+	if state.OnDestroy.IsNull() {
+		state.OnDestroy = types.StringValue(ON_DESTROY)
 	}
 
 	// set refreshed state
@@ -182,28 +383,26 @@ func (r *userRulesResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	// instantiate empty client for storing plan data
-	var userRules adgmodels.SetRulesRequest
-
-	// populate user rules from plan
-	if len(plan.Rules.Elements()) > 0 {
-		diags = plan.Rules.ElementsAs(ctx, &userRules.Rules, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	}
+	// ensure no other user rules resource changes the user rules in the meantime
+	userRulesMutex.Lock()
+	defer userRulesMutex.Unlock()
 
-	// update user rules using plan
-	err := r.adg.FilteringSetRules(userRules)
+	// retrieve the current user rules
+	allFilters, err := r.adg.FilteringStatus()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating AdGuard Home User Rules",
-			"Could not update user rules, unexpected error: "+err.Error(),
+			"Could not read current user rules, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
+	// defer to common function to create or update the resource
+	r.CreateOrUpdate(ctx, &plan, allFilters.UserRules, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// populate plan with computed attributes
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
@@ -228,8 +427,65 @@ func (r *userRulesResource) Delete(ctx context.Context, req resource.DeleteReque
 	// empty variable
 	var userRules adgmodels.SetRulesRequest
 
-	// delete existing user rules
-	err := r.adg.FilteringSetRules(userRules)
+	switch state.OnDestroy.ValueString() {
+	case "retain":
+		// leave the user rules as they are
+		return
+	case "restore_previous":
+		// a section did not exist before being created, so removing it restores the previous user rules
+		if !state.Section.IsNull() {
+			break
+		}
+
+		// retrieve the user rules captured at creation
+		snapshotJson, diags := req.Private.GetKey(ctx, snapshotPrivateStateKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if snapshotJson == nil {
+			resp.Diagnostics.AddWarning(
+				"No Previous AdGuard Home User Rules to Restore",
+				"The user rules were not created by Terraform (e.g. they were imported), so the current user rules are retained",
+			)
+			return
+		}
+		err := json.Unmarshal(snapshotJson, &userRules.Rules)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting AdGuard Home User Rules",
+				"Could not parse previous user rules, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// ensure no other user rules resource changes the user rules in the meantime
+	userRulesMutex.Lock()
+	defer userRulesMutex.Unlock()
+
+	// retrieve the current user rules
+	allFilters, err := r.adg.FilteringStatus()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting AdGuard Home User Rules",
+			"Could not read current user rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !state.Section.IsNull() {
+		// remove only the section, keeping all other user rules
+		userRules.Rules = removeUserRulesSection(allFilters.UserRules, state.Section.ValueString())
+	} else {
+		// keep the sections managed by other resources
+		userRules.Rules, _ = splitUserRulesSections(userRules.Rules)
+		_, sectionLines := splitUserRulesSections(allFilters.UserRules)
+		userRules.Rules = append(userRules.Rules, sectionLines...)
+	}
+
+	// delete existing user rules, or restore the previous ones
+	err = r.adg.FilteringSetRules(userRules)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting AdGuard Home User Rules",
@@ -240,6 +496,72 @@ func (r *userRulesResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *userRulesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// a section is imported by its prefixed name
+	if section, ok := strings.CutPrefix(req.ID, userRulesSectionIdPrefix); ok {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("section"), section)...)
+	} else if req.ID != "1" {
+		resp.Diagnostics.AddError(
+			"Invalid AdGuard Home User Rules Import ID",
+			"Expected import ID `1` for the entire user rules, or `"+userRulesSectionIdPrefix+"<section>` for a section, got: "+req.ID,
+		)
+		return
+	}
+
 	// retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// CreateOrUpdate - common function to create or update the user rules
+func (r *userRulesResource) CreateOrUpdate(ctx context.Context, plan *userRulesResourceModel, currentRules []string, diags *diag.Diagnostics) {
+	// instantiate empty object for storing plan data
+	var userRules adgmodels.SetRulesRequest
+
+	// populate raw user rules from plan
+	var planRules []string
+	if len(plan.Rules.Elements()) > 0 {
+		d := plan.Rules.ElementsAs(ctx, &planRules, false)
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	// populate structured user rules from plan
+	var planStructuredRules []userRule
+	if !plan.Rule.IsNull() {
+		var planRuleModels []userRuleModel
+		d := plan.Rule.ElementsAs(ctx, &planRuleModels, false)
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+		for _, planRuleModel := range planRuleModels {
+			planStructuredRules = append(planStructuredRules, planRuleModel.toUserRule(ctx, diags))
+		}
+		if diags.HasError() {
+			return
+		}
+	}
+
+	// render all user rules in AdGuard filtering syntax
+	planRules = renderUserRules(planRules, planStructuredRules)
+
+	if plan.Section.IsNull() {
+		// the plan holds the entire user rules, except for the sections managed by other resources
+		_, sectionLines := splitUserRulesSections(currentRules)
+		userRules.Rules = append(planRules, sectionLines...)
+	} else {
+		// the plan only holds the rules within the section
+		userRules.Rules = setUserRulesSection(currentRules, plan.Section.ValueString(), planRules)
+	}
+
+	// set user rules using plan
+	err := r.adg.FilteringSetRules(userRules)
+	if err != nil {
+		diags.AddError(
+			"Unable to Update AdGuard Home User Rules",
+			err.Error(),
+		)
+		return
+	}
+}
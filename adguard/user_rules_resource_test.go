@@ -1,15 +1,40 @@
 package adguard
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccUserRulesResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
+			// Invalid rule testing
+			{
+				Config: providerConfig + `
+resource "adguard_user_rules" "test" {
+  rules = [
+    "||blocked.org^$third-party"
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid User Rule"),
+			},
+			// Invalid $dnsrewrite value testing
+			{
+				Config: providerConfig + `
+resource "adguard_user_rules" "test" {
+  rules = [
+    "||rewritten.org^$dnsrewrite=NOERROR;A;rewritten.example.org"
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile("must be an IPv4 address"),
+			},
 			// Create and Read testing
 			{
 				Config: providerConfig + `
@@ -50,13 +75,268 @@ resource "adguard_user_rules" "test" {
 		"# line 3 block access to blocked.org and all its subdomains",
 		"||blocked.org^"
 	]
+  on_destroy = "restore_previous"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("adguard_user_rules.test", "rules.#", "4"),
+					resource.TestCheckResourceAttr("adguard_user_rules.test", "on_destroy", "restore_previous"),
+				),
+			},
+			// Invalid $dnsrewrite response code testing
+			{
+				Config: providerConfig + `
+resource "adguard_user_rules" "test" {
+  rules = [
+    "||rewritten.org^$dnsrewrite=NOTACODE;;"
+  ]
+  on_destroy = "restore_previous"
+}
+`,
+				ExpectError: regexp.MustCompile("unknown response code"),
+			},
+			// Invalid $dnsrewrite MX value testing
+			{
+				Config: providerConfig + `
+resource "adguard_user_rules" "test" {
+  rules = [
+    "||mail.rewritten.org^$dnsrewrite=NOERROR;MX;mx.rewritten.org"
+  ]
+  on_destroy = "restore_previous"
+}
+`,
+				ExpectError: regexp.MustCompile("must be a preference followed by a hostname"),
+			},
+			// Update with $dnsrewrite rules testing
+			{
+				Config: providerConfig + `
+resource "adguard_user_rules" "test" {
+  rules = [
+		"# line 1 unblock access to unblocked.org and all its subdomains",
+		"@@||unblocked.org^",
+		"# line 3 block access to blocked.org and all its subdomains",
+		"||blocked.org^",
+		"@@||rewritten.org^$dnsrewrite",
+		"||mail.rewritten.org^$dnsrewrite=NOERROR;MX;10 mx.rewritten.org",
+		"||notimp.rewritten.org^$dnsrewrite=NOTIMP;;"
+	]
+  on_destroy = "restore_previous"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_user_rules.test", "rules.#", "7"),
+					resource.TestCheckResourceAttr("adguard_user_rules.test", "rules.6", "||notimp.rewritten.org^$dnsrewrite=NOTIMP;;"),
+				),
+			},
+			// Replace with section testing
+			{
+				Config: providerConfig + `
+resource "adguard_user_rules" "section" {
+  section = "test-section"
+  rules = [
+    "# block access to section-blocked.org and all its subdomains",
+    "||section-blocked.org^"
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "id", "section:test-section"),
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "section", "test-section"),
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "rules.#", "2"),
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "rules.1", "||section-blocked.org^"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "adguard_user_rules.section",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Invalid structured rule testing
+			{
+				Config: providerConfig + `
+resource "adguard_user_rules" "section" {
+  section = "test-section"
+  rule = [
+    {
+      pattern = "||games|example.org^"
+    }
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid User Rule"),
+			},
+			// Update with structured rules testing
+			{
+				Config: providerConfig + `
+resource "adguard_user_rules" "section" {
+  section = "test-section"
+  rules = [
+    "# block access to section-blocked.org and all its subdomains",
+    "||section-blocked.org^"
+  ]
+  rule = [
+    {
+      pattern = "||games.example.org^"
+      client  = ["192.168.100.15"]
+      comment = "no games for this client"
+    },
+    {
+      pattern   = "||school.example.org^"
+      action    = "allow"
+      important = true
+    }
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "rules.#", "2"),
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "rule.#", "2"),
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "rule.0.pattern", "||games.example.org^"),
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "rule.1.pattern", "||school.example.org^"),
+					resource.TestCheckTypeSetElemNestedAttrs("adguard_user_rules.section", "rule.*", map[string]string{
+						"pattern":  "||games.example.org^",
+						"action":   "block",
+						"client.#": "1",
+						"comment":  "no games for this client",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("adguard_user_rules.section", "rule.*", map[string]string{
+						"pattern":   "||school.example.org^",
+						"action":    "allow",
+						"important": "true",
+					}),
+				),
+			},
+			// Structured rules order testing
+			{
+				Config: providerConfig + `
+resource "adguard_user_rules" "section" {
+  section = "test-section"
+  rules = [
+    "# block access to section-blocked.org and all its subdomains",
+    "||section-blocked.org^"
+  ]
+  rule = [
+    {
+      pattern   = "||school.example.org^"
+      action    = "allow"
+      important = true
+    },
+    {
+      pattern = "||games.example.org^"
+      client  = ["192.168.100.15"]
+      comment = "no games for this client"
+    }
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "rule.#", "2"),
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "rule.0.pattern", "||school.example.org^"),
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "rule.1.pattern", "||games.example.org^"),
+				),
+			},
+			// ImportState testing with structured rules
+			{
+				ResourceName:      "adguard_user_rules.section",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// import only populates the raw rules, as the structured form cannot be told
+				// apart from the raw one without a configuration
+				ImportStateVerifyIgnore: []string{"last_updated", "rules", "rule"},
+			},
+			// Reserved section marker testing
+			{
+				Config: providerConfig + `
+resource "adguard_user_rules" "test" {
+  rules = [
+    "! terraform:begin test-section",
+    "||blocked.org^"
+  ]
+}
+
+resource "adguard_user_rules" "section" {
+  section = "test-section"
+  rules = [
+    "# block access to section-blocked.org and all its subdomains",
+    "||section-blocked.org^"
+  ]
+  rule = [
+    {
+      pattern = "||games.example.org^"
+      client  = ["192.168.100.15"]
+      comment = "no games for this client"
+    },
+    {
+      pattern   = "||school.example.org^"
+      action    = "allow"
+      important = true
+    }
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile("reserved for user rules sections"),
+			},
+			// Entire user rules alongside a section testing
+			{
+				Config: providerConfig + `
+resource "adguard_user_rules" "test" {
+  rules = [
+    "||blocked.org^"
+  ]
+
+  depends_on = [adguard_user_rules.section]
+}
+
+resource "adguard_user_rules" "section" {
+  section = "test-section"
+  rules = [
+    "# block access to section-blocked.org and all its subdomains",
+    "||section-blocked.org^"
+  ]
+  rule = [
+    {
+      pattern = "||games.example.org^"
+      client  = ["192.168.100.15"]
+      comment = "no games for this client"
+    },
+    {
+      pattern   = "||school.example.org^"
+      action    = "allow"
+      important = true
+    }
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("adguard_user_rules.test", "id", "1"),
+					resource.TestCheckResourceAttr("adguard_user_rules.test", "rules.#", "1"),
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "rules.#", "2"),
+					resource.TestCheckResourceAttr("adguard_user_rules.section", "rule.#", "2"),
+					testAccCheckUserRulesSectionExists("test-section"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func testAccCheckUserRulesSectionExists(section string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		adg, err := testAccAdguardClient()
+		if err != nil {
+			return err
+		}
+
+		allFilters, err := adg.FilteringStatus()
+		if err != nil {
+			return err
+		}
+		if _, found := getUserRulesSection(allFilters.UserRules, section); !found {
+			return fmt.Errorf("user rules section %s was removed", section)
+		}
+
+		return nil
+	}
+}
@@ -52,6 +52,16 @@ func convertToAttr(elems []string) []attr.Value {
 	return output
 }
 
+// converts an array of string to array of attr.Value of domainType
+func convertToDomainAttr(elems []string) []attr.Value {
+	var output []attr.Value
+
+	for _, item := range elems {
+		output = append(output, newDomainValue(item))
+	}
+	return output
+}
+
 // given a duration in milliseconds, convert to a HH:MM string
 func convertMsToHourMinutes(duration_ms int64) string {
 	// convert provided interval in ms to duration
@@ -0,0 +1,54 @@
+package adguard
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validator confirms the DNS access list is not set when the config resource is not managing it
+var _ validator.Bool = checkAccessListValidator{}
+
+type checkAccessListValidator struct {
+}
+
+func (v checkAccessListValidator) Description(_ context.Context) string {
+	return "\"dns.allowed_clients\", \"dns.disallowed_clients\" and \"dns.blocked_hosts\" cannot be set when \"dns.manage_access_list\" is set to `false`"
+}
+
+func (v checkAccessListValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v checkAccessListValidator) ValidateBool(ctx context.Context, req validator.BoolRequest, resp *validator.BoolResponse) {
+	if req.ConfigValue.ValueBool() || req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		// if set to true, null or unknown, config is valid
+		return
+	}
+
+	for _, name := range []string{"allowed_clients", "disallowed_clients", "blocked_hosts"} {
+		accessListPath := req.Path.ParentPath().AtName(name)
+
+		var accessList types.Set
+
+		diags := req.Config.GetAttribute(ctx, accessListPath, &accessList)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !accessList.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				accessListPath,
+				"DNS Access List Config Invalid",
+				v.Description(ctx),
+			)
+		}
+	}
+}
+
+func checkAccessList() validator.Bool {
+	return checkAccessListValidator{}
+}
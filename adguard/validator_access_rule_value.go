@@ -0,0 +1,56 @@
+package adguard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validator confirms the value of a blocked host DNS access rule is a valid domain name
+var _ validator.String = checkAccessRuleValueValidator{}
+
+type checkAccessRuleValueValidator struct {
+}
+
+func (v checkAccessRuleValueValidator) Description(_ context.Context) string {
+	return "value must be a valid domain name, optionally starting with a `*.` wildcard label, when type is `blocked_host`"
+}
+
+func (v checkAccessRuleValueValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v checkAccessRuleValueValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		// nothing to validate
+		return
+	}
+
+	var ruleType types.String
+
+	diags := req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("type"), &ruleType)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if ruleType.ValueString() != "blocked_host" {
+		// client values are checked by AdGuard Home
+		return
+	}
+
+	if err := validateDomain(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Domain",
+			fmt.Sprintf("Domain %q is invalid: %s", req.ConfigValue.ValueString(), err.Error()),
+		)
+	}
+}
+
+func checkAccessRuleValue() validator.String {
+	return checkAccessRuleValueValidator{}
+}
@@ -0,0 +1,140 @@
+package adguard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// validator confirms each client ID is a valid IP address, CIDR, MAC address or ClientID
+var _ validator.Set = checkClientIdsValidator{}
+
+type checkClientIdsValidator struct {
+}
+
+// client ID types supported by AdGuard Home
+const (
+	clientIdTypeIp       = "IP"
+	clientIdTypeCidr     = "CIDR"
+	clientIdTypeMac      = "MAC"
+	clientIdTypeClientId = "ClientID"
+)
+
+// ClientIDs can only contain lowercase letters, numbers and hyphens
+var clientIdRegexp = regexp.MustCompile(`^[a-z0-9-]{1,63}$`)
+
+func (v checkClientIdsValidator) Description(_ context.Context) string {
+	return "each client ID must be a valid IP address, CIDR, MAC address or ClientID"
+}
+
+func (v checkClientIdsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v checkClientIdsValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		// nothing to validate
+		return
+	}
+
+	for _, element := range req.ConfigValue.Elements() {
+		valuable, ok := element.(basetypes.StringValuable)
+		if !ok {
+			continue
+		}
+		id, diags := valuable.ToStringValue(ctx)
+		resp.Diagnostics.Append(diags...)
+		if id.IsNull() || id.IsUnknown() {
+			continue
+		}
+
+		if _, err := classifyClientId(id.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtSetValue(element),
+				"Invalid Client ID",
+				fmt.Sprintf("Client ID %q is invalid: %s", id.ValueString(), err.Error()),
+			)
+		}
+	}
+}
+
+// classifyClientId - returns the type of a client ID, or an error if it is not valid for any type
+func classifyClientId(id string) (string, error) {
+	if _, err := netip.ParseAddr(id); err == nil {
+		return clientIdTypeIp, nil
+	}
+
+	if strings.Contains(id, "/") {
+		if _, err := netip.ParsePrefix(id); err != nil {
+			return "", fmt.Errorf("not a valid CIDR")
+		}
+		return clientIdTypeCidr, nil
+	}
+
+	if _, err := net.ParseMAC(id); err == nil {
+		return clientIdTypeMac, nil
+	}
+
+	if strings.ContainsAny(id, ":.") {
+		return "", fmt.Errorf("not a valid IP or MAC address")
+	}
+
+	if !clientIdRegexp.MatchString(id) {
+		return "", fmt.Errorf("ClientIDs can only contain lowercase letters, numbers and hyphens, up to 63 characters")
+	}
+
+	return clientIdTypeClientId, nil
+}
+
+// normalizeClientId - returns the canonical form of a client ID, so equivalent IDs compare equal
+func normalizeClientId(id string) string {
+	if addr, err := netip.ParseAddr(id); err == nil {
+		return addr.Unmap().String()
+	}
+	if prefix, err := netip.ParsePrefix(id); err == nil {
+		return prefix.Masked().String()
+	}
+	if mac, err := net.ParseMAC(id); err == nil {
+		return mac.String()
+	}
+	return id
+}
+
+// clientIdsOverlap - returns whether two client IDs match the same clients, either by being
+// equivalent or by an IP address or CIDR falling within another CIDR
+func clientIdsOverlap(a string, b string) bool {
+	if normalizeClientId(a) == normalizeClientId(b) {
+		return true
+	}
+
+	prefixA, errA := parseClientIdPrefix(a)
+	prefixB, errB := parseClientIdPrefix(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return prefixA.Overlaps(prefixB)
+}
+
+// parseClientIdPrefix - parses an IP address or CIDR client ID as a prefix
+func parseClientIdPrefix(id string) (netip.Prefix, error) {
+	if addr, err := netip.ParseAddr(id); err == nil {
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(id)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return prefix.Masked(), nil
+}
+
+func checkClientIds() validator.Set {
+	return checkClientIdsValidator{}
+}
@@ -0,0 +1,203 @@
+package adguard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// validator confirms the DHCP IPv4 range and static leases are consistent with the DHCP subnet
+var _ resource.ConfigValidator = checkDhcpConfigValidator{}
+
+type checkDhcpConfigValidator struct {
+}
+
+func (v checkDhcpConfigValidator) Description(_ context.Context) string {
+	return "\"dhcp.ipv4_settings\" range must be within the subnet and exclude the gateway, " +
+		"and \"dhcp.static_leases\" must be within the subnet with unique IP addresses, MAC addresses and hostnames"
+}
+
+func (v checkDhcpConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v checkDhcpConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	dhcpPath := path.Root("dhcp")
+
+	var dhcp types.Object
+	diags := req.Config.GetAttribute(ctx, dhcpPath, &dhcp)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || dhcp.IsNull() || dhcp.IsUnknown() {
+		// nothing to validate
+		return
+	}
+
+	var dhcpConfig dhcpConfigModel
+	diags = dhcp.As(ctx, &dhcpConfig, basetypes.ObjectAsOptions{})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the subnet is only known when the IPv4 settings are fully known
+	var subnet *net.IPNet
+	if !dhcpConfig.Ipv4Settings.IsNull() && !dhcpConfig.Ipv4Settings.IsUnknown() {
+		var dhcpIpv4Config dhcpIpv4Model
+		diags = dhcpConfig.Ipv4Settings.As(ctx, &dhcpIpv4Config, basetypes.ObjectAsOptions{})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		subnet = v.validateIpv4Settings(dhcpPath.AtName("ipv4_settings"), dhcpIpv4Config, resp)
+	}
+
+	if !dhcpConfig.StaticLeases.IsNull() && !dhcpConfig.StaticLeases.IsUnknown() {
+		v.validateStaticLeases(ctx, dhcpPath.AtName("static_leases"), dhcpConfig.StaticLeases, subnet, resp)
+	}
+}
+
+// validateIpv4Settings - validates the IPv4 range against the subnet, returning the subnet if it can be determined
+func (v checkDhcpConfigValidator) validateIpv4Settings(ipv4Path path.Path, dhcpIpv4Config dhcpIpv4Model, resp *resource.ValidateConfigResponse) *net.IPNet {
+	for _, value := range []types.String{dhcpIpv4Config.GatewayIp, dhcpIpv4Config.SubnetMask, dhcpIpv4Config.RangeStart, dhcpIpv4Config.RangeEnd} {
+		if value.IsNull() || value.IsUnknown() {
+			// cannot validate until all values are known
+			return nil
+		}
+	}
+
+	gatewayIp := net.ParseIP(dhcpIpv4Config.GatewayIp.ValueString()).To4()
+	rangeStart := net.ParseIP(dhcpIpv4Config.RangeStart.ValueString()).To4()
+	rangeEnd := net.ParseIP(dhcpIpv4Config.RangeEnd.ValueString()).To4()
+	if gatewayIp == nil || rangeStart == nil || rangeEnd == nil {
+		// invalid IP addresses are reported by the attribute validators
+		return nil
+	}
+
+	subnetMask := net.IPMask(net.ParseIP(dhcpIpv4Config.SubnetMask.ValueString()).To4())
+	if ones, bits := subnetMask.Size(); bits == 0 || ones == 0 {
+		resp.Diagnostics.AddAttributeError(
+			ipv4Path.AtName("subnet_mask"),
+			"DHCP Config Invalid",
+			fmt.Sprintf("%q is not a valid subnet mask", dhcpIpv4Config.SubnetMask.ValueString()),
+		)
+		return nil
+	}
+
+	subnet := &net.IPNet{IP: gatewayIp.Mask(subnetMask), Mask: subnetMask}
+
+	for _, rangeIp := range []struct {
+		name string
+		ip   net.IP
+	}{
+		{"range_start", rangeStart},
+		{"range_end", rangeEnd},
+	} {
+		if !subnet.Contains(rangeIp.ip) {
+			resp.Diagnostics.AddAttributeError(
+				ipv4Path.AtName(rangeIp.name),
+				"DHCP Config Invalid",
+				fmt.Sprintf("%s %s is not within the subnet %s defined by the gateway IP and subnet mask", rangeIp.name, rangeIp.ip, subnet),
+			)
+		}
+	}
+
+	if bytes.Compare(rangeStart, rangeEnd) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			ipv4Path.AtName("range_end"),
+			"DHCP Config Invalid",
+			fmt.Sprintf("range_end %s must not be lower than range_start %s", rangeEnd, rangeStart),
+		)
+	} else if bytes.Compare(gatewayIp, rangeStart) >= 0 && bytes.Compare(gatewayIp, rangeEnd) <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			ipv4Path.AtName("gateway_ip"),
+			"DHCP Config Invalid",
+			fmt.Sprintf("gateway_ip %s must not be within the range %s - %s", gatewayIp, rangeStart, rangeEnd),
+		)
+	}
+
+	return subnet
+}
+
+// validateStaticLeases - validates the static leases are within the subnet and unique
+func (v checkDhcpConfigValidator) validateStaticLeases(ctx context.Context, staticLeasesPath path.Path, staticLeases types.Set, subnet *net.IPNet, resp *resource.ValidateConfigResponse) {
+	seenIps := make(map[string]bool)
+	seenMacs := make(map[string]bool)
+	seenHostnames := make(map[string]bool)
+
+	for _, element := range staticLeases.Elements() {
+		leaseObject, ok := element.(types.Object)
+		if !ok || leaseObject.IsNull() || leaseObject.IsUnknown() {
+			continue
+		}
+
+		var staticLease dhcpStaticLeasesModel
+		diags := leaseObject.As(ctx, &staticLease, basetypes.ObjectAsOptions{})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		leasePath := staticLeasesPath.AtSetValue(leaseObject)
+
+		if !staticLease.Ip.IsNull() && !staticLease.Ip.IsUnknown() {
+			ip := net.ParseIP(staticLease.Ip.ValueString())
+			if ip != nil && subnet != nil && !subnet.Contains(ip) {
+				resp.Diagnostics.AddAttributeError(
+					leasePath.AtName("ip"),
+					"DHCP Static Leases Config Invalid",
+					fmt.Sprintf("static lease IP address %s is not within the subnet %s", ip, subnet),
+				)
+			}
+			if ip != nil {
+				if seenIps[ip.String()] {
+					resp.Diagnostics.AddAttributeError(
+						leasePath.AtName("ip"),
+						"DHCP Static Leases Config Invalid",
+						fmt.Sprintf("static lease IP address %s is used more than once", ip),
+					)
+				}
+				seenIps[ip.String()] = true
+			}
+		}
+
+		if !staticLease.Mac.IsNull() && !staticLease.Mac.IsUnknown() {
+			// compare MAC addresses in their canonical form
+			mac := strings.ToLower(staticLease.Mac.ValueString())
+			if hardwareAddr, err := net.ParseMAC(mac); err == nil {
+				mac = hardwareAddr.String()
+			}
+			if seenMacs[mac] {
+				resp.Diagnostics.AddAttributeError(
+					leasePath.AtName("mac"),
+					"DHCP Static Leases Config Invalid",
+					fmt.Sprintf("static lease MAC address %s is used more than once", staticLease.Mac.ValueString()),
+				)
+			}
+			seenMacs[mac] = true
+		}
+
+		if !staticLease.Hostname.IsNull() && !staticLease.Hostname.IsUnknown() {
+			hostname := strings.ToLower(staticLease.Hostname.ValueString())
+			if seenHostnames[hostname] {
+				resp.Diagnostics.AddAttributeError(
+					leasePath.AtName("hostname"),
+					"DHCP Static Leases Config Invalid",
+					fmt.Sprintf("static lease hostname %s is used more than once", staticLease.Hostname.ValueString()),
+				)
+			}
+			seenHostnames[hostname] = true
+		}
+	}
+}
+
+func checkDhcpConfig() resource.ConfigValidator {
+	return checkDhcpConfigValidator{}
+}
@@ -0,0 +1,85 @@
+package adguard
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// validator confirms a value is a valid domain name, in Unicode or punycode, optionally with a leading wildcard
+var _ validator.String = checkDomainValidator{}
+
+type checkDomainValidator struct {
+}
+
+// labels of a domain in its ASCII form, allowing underscores as used in service records
+var domainLabelRegexp = regexp.MustCompile(`^[a-z0-9_]([a-z0-9_-]*[a-z0-9_])?$`)
+
+// hostnames, optionally internationalized and fully qualified, as used in user rules and upstream DNS servers
+var hostnameRegexp = regexp.MustCompile(`^[\p{L}\p{N}_]([\p{L}\p{N}_-]*[\p{L}\p{N}_])?(\.[\p{L}\p{N}_]([\p{L}\p{N}_-]*[\p{L}\p{N}_])?)*\.?$`)
+
+func (v checkDomainValidator) Description(_ context.Context) string {
+	return "value must be a valid domain name, optionally starting with a `*.` wildcard label"
+}
+
+func (v checkDomainValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v checkDomainValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		// nothing to validate
+		return
+	}
+
+	if err := validateDomain(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Domain",
+			fmt.Sprintf("Domain %q is invalid: %s", req.ConfigValue.ValueString(), err.Error()),
+		)
+	}
+}
+
+// validateDomain - returns an error if a domain is not valid in either Unicode or punycode spelling
+func validateDomain(domain string) error {
+	name, _ := strings.CutPrefix(domain, "*.")
+	if strings.Contains(name, "*") {
+		return fmt.Errorf("a wildcard is only allowed as the leftmost label, as in `*.example.org`")
+	}
+
+	ascii, err := domainIdnaProfile.ToASCII(name)
+	if err != nil {
+		return fmt.Errorf("cannot convert to punycode: %w", err)
+	}
+
+	// a single trailing dot denotes a fully qualified domain
+	ascii = strings.TrimSuffix(strings.ToLower(ascii), ".")
+	if ascii == "" {
+		return fmt.Errorf("the domain is empty")
+	}
+	if len(ascii) > 253 {
+		return fmt.Errorf("the domain is %d characters long in punycode, exceeding the maximum of 253", len(ascii))
+	}
+
+	for _, label := range strings.Split(ascii, ".") {
+		if label == "" {
+			return fmt.Errorf("the domain contains an empty label")
+		}
+		if len(label) > 63 {
+			return fmt.Errorf("the label %q is %d characters long in punycode, exceeding the maximum of 63", label, len(label))
+		}
+		if !domainLabelRegexp.MatchString(label) {
+			return fmt.Errorf("the label %q can only contain letters, numbers, hyphens and underscores, and cannot start or end with a hyphen", label)
+		}
+	}
+
+	return nil
+}
+
+func checkDomain() validator.String {
+	return checkDomainValidator{}
+}
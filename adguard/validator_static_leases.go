@@ -0,0 +1,52 @@
+package adguard
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validator confirms the DHCP static leases are not set when the config resource is not managing them
+var _ validator.Bool = checkStaticLeasesValidator{}
+
+type checkStaticLeasesValidator struct {
+}
+
+func (v checkStaticLeasesValidator) Description(_ context.Context) string {
+	return "\"dhcp.static_leases\" cannot be set when \"dhcp.manage_static_leases\" is set to `false`"
+}
+
+func (v checkStaticLeasesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v checkStaticLeasesValidator) ValidateBool(ctx context.Context, req validator.BoolRequest, resp *validator.BoolResponse) {
+	if req.ConfigValue.ValueBool() || req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		// if set to true, null or unknown, config is valid
+		return
+	}
+
+	staticLeasesPath := req.Path.ParentPath().AtName("static_leases")
+
+	var staticLeases types.Set
+
+	diags := req.Config.GetAttribute(ctx, staticLeasesPath, &staticLeases)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !staticLeases.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			staticLeasesPath,
+			"DHCP Static Leases Config Invalid",
+			v.Description(ctx),
+		)
+	}
+}
+
+func checkStaticLeases() validator.Bool {
+	return checkStaticLeasesValidator{}
+}
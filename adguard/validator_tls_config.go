@@ -0,0 +1,62 @@
+package adguard
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validator confirms a certificate and a private key are provided when encryption is enabled
+var _ resource.ConfigValidator = checkTlsConfigValidator{}
+
+type checkTlsConfigValidator struct {
+}
+
+func (v checkTlsConfigValidator) Description(_ context.Context) string {
+	return "when \"enabled\" is `true`, one of \"certificate_chain\" or \"certificate_path\" " +
+		"and one of \"private_key\" or \"private_key_path\" must be specified"
+}
+
+func (v checkTlsConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v checkTlsConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var enabled types.Bool
+	diags := req.Config.GetAttribute(ctx, path.Root("enabled"), &enabled)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || enabled.IsUnknown() || !enabled.ValueBool() {
+		// certificate and private key are only required when encryption is enabled
+		return
+	}
+
+	for _, pair := range [][2]string{{"certificate_chain", "certificate_path"}, {"private_key", "private_key_path"}} {
+		var inline, file types.String
+		diags = req.Config.GetAttribute(ctx, path.Root(pair[0]), &inline)
+		resp.Diagnostics.Append(diags...)
+		diags = req.Config.GetAttribute(ctx, path.Root(pair[1]), &file)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if inline.IsUnknown() || file.IsUnknown() {
+			// cannot validate unknown values
+			continue
+		}
+
+		if inline.IsNull() && file.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(pair[0]),
+				"Invalid Attribute Combination",
+				"One of \""+pair[0]+"\" or \""+pair[1]+"\" must be specified when \"enabled\" is `true`",
+			)
+		}
+	}
+}
+
+func checkTlsConfig() resource.ConfigValidator {
+	return checkTlsConfigValidator{}
+}
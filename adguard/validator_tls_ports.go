@@ -0,0 +1,79 @@
+package adguard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validator confirms the HTTPS port does not conflict with the encrypted DNS ports
+var _ validator.Int64 = checkTlsPortsValidator{}
+
+type checkTlsPortsValidator struct {
+}
+
+func (v checkTlsPortsValidator) Description(_ context.Context) string {
+	return "\"port_https\" must be different from \"port_dns_over_tls\" and \"port_dns_over_quic\", unless set to `0`"
+}
+
+func (v checkTlsPortsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v checkTlsPortsValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsUnknown() {
+		// cannot validate unknown values
+		return
+	}
+
+	// HTTPS uses both TCP and UDP (for HTTP/3), while DoT only uses TCP and DoQ only uses UDP,
+	// therefore DoT and DoQ can share the same port, but neither of them can share it with HTTPS
+	portHttps := int64(CONFIG_TLS_PORT_HTTPS)
+	if !req.ConfigValue.IsNull() {
+		portHttps = req.ConfigValue.ValueInt64()
+	}
+	if portHttps == 0 {
+		// HTTPS is disabled, config is valid
+		return
+	}
+
+	// defaults to be used when the other ports are not set
+	otherPorts := map[string]int64{
+		"port_dns_over_tls":  CONFIG_TLS_PORT_DNS_OVER_TLS,
+		"port_dns_over_quic": CONFIG_TLS_PORT_DNS_OVER_QUIC,
+	}
+
+	for _, name := range []string{"port_dns_over_tls", "port_dns_over_quic"} {
+		otherPortPath := req.Path.ParentPath().AtName(name)
+
+		var otherPort types.Int64
+
+		diags := req.Config.GetAttribute(ctx, otherPortPath, &otherPort)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if otherPort.IsUnknown() {
+			continue
+		}
+		if !otherPort.IsNull() {
+			otherPorts[name] = otherPort.ValueInt64()
+		}
+
+		if otherPorts[name] == portHttps {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"TLS Ports Config Invalid",
+				fmt.Sprintf("%s, but both \"port_https\" and %q are set to `%d`", v.Description(ctx), name, portHttps),
+			)
+		}
+	}
+}
+
+func checkTlsPorts() validator.Int64 {
+	return checkTlsPortsValidator{}
+}
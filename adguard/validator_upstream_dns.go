@@ -0,0 +1,220 @@
+package adguard
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validator confirms each upstream DNS server is in a format supported by AdGuard Home
+var (
+	_ validator.List = checkUpstreamDnsValidator{}
+	_ validator.Set  = checkUpstreamDnsValidator{}
+)
+
+type checkUpstreamDnsValidator struct {
+	// bootstrap DNS servers must be plain IP addresses
+	bootstrap bool
+}
+
+// schemes supported by AdGuard Home for upstream DNS servers
+var upstreamDnsSchemes = []string{"udp", "tcp", "tls", "https", "quic", "h3", "sdns"}
+
+func (v checkUpstreamDnsValidator) Description(_ context.Context) string {
+	if v.bootstrap {
+		return "each bootstrap DNS server must be a plain IP address, optionally with a port"
+	}
+	return "each upstream DNS server must be a plain IP address or hostname, optionally with a port, " +
+		"a `tcp://`, `tls://`, `https://`, `quic://`, `h3://` or `sdns://` URL, optionally prefixed by `[/domain/]`, or a `#` comment"
+}
+
+func (v checkUpstreamDnsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v checkUpstreamDnsValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		// nothing to validate
+		return
+	}
+
+	for i, element := range req.ConfigValue.Elements() {
+		upstream, ok := element.(types.String)
+		if !ok || upstream.IsNull() || upstream.IsUnknown() {
+			continue
+		}
+
+		if reason := v.validateUpstream(upstream.ValueString()); reason != "" {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i),
+				"Invalid Upstream DNS Server",
+				fmt.Sprintf("Upstream DNS server at index %d (%q) is invalid: %s", i, upstream.ValueString(), reason),
+			)
+		}
+	}
+}
+
+func (v checkUpstreamDnsValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		// nothing to validate
+		return
+	}
+
+	for _, element := range req.ConfigValue.Elements() {
+		upstream, ok := element.(types.String)
+		if !ok || upstream.IsNull() || upstream.IsUnknown() {
+			continue
+		}
+
+		if reason := v.validateUpstream(upstream.ValueString()); reason != "" {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtSetValue(upstream),
+				"Invalid Upstream DNS Server",
+				fmt.Sprintf("Upstream DNS server %q is invalid: %s", upstream.ValueString(), reason),
+			)
+		}
+	}
+}
+
+// validateUpstream - returns the reason why an upstream DNS server is invalid, or an empty string if it is valid
+func (v checkUpstreamDnsValidator) validateUpstream(upstream string) string {
+	upstream = strings.TrimSpace(upstream)
+
+	if upstream == "" {
+		return "the value is empty"
+	}
+
+	if v.bootstrap {
+		return validateUpstreamPlainIP(upstream)
+	}
+
+	// comments are ignored by AdGuard Home
+	if strings.HasPrefix(upstream, "#") {
+		return ""
+	}
+
+	// upstreams for specific domains, in the form `[/domain1/domain2/]upstream1 upstream2`,
+	// or `[//]upstream1 upstream2` for unqualified names
+	if strings.HasPrefix(upstream, "[/") {
+		end := strings.Index(upstream[2:], "/]")
+		if end == -1 {
+			return "domain-specific upstreams must be in the form `[/domain/]upstream`"
+		}
+		end += 2
+		if domains := upstream[2:end]; domains != "" {
+			for _, domain := range strings.Split(domains, "/") {
+				if domain == "" || !hostnameRegexp.MatchString(strings.TrimPrefix(domain, "*.")) {
+					return fmt.Sprintf("%q is not a valid domain", domain)
+				}
+			}
+		}
+
+		upstreams := strings.Fields(upstream[end+2:])
+		if len(upstreams) == 0 {
+			return "domain-specific upstreams must specify at least one upstream, or `#` to use the default upstreams"
+		}
+		for _, u := range upstreams {
+			// `#` means the default upstreams are used for the domains
+			if u == "#" {
+				continue
+			}
+			if reason := validateUpstreamAddress(u); reason != "" {
+				return reason
+			}
+		}
+		return ""
+	}
+
+	return validateUpstreamAddress(upstream)
+}
+
+// validateUpstreamPlainIP - validates a plain IP address, optionally with a port
+func validateUpstreamPlainIP(upstream string) string {
+	if net.ParseIP(upstream) != nil {
+		return ""
+	}
+
+	host, port, err := net.SplitHostPort(upstream)
+	if err != nil || net.ParseIP(host) == nil {
+		return "bootstrap DNS servers must be plain IP addresses, optionally with a port"
+	}
+
+	return validateUpstreamPort(port)
+}
+
+// validateUpstreamAddress - validates a single upstream address, with or without a scheme
+func validateUpstreamAddress(upstream string) string {
+	scheme, rest, found := strings.Cut(upstream, "://")
+	if !found {
+		// plain DNS over UDP
+		return validateUpstreamHostPort(upstream)
+	}
+
+	if !contains(upstreamDnsSchemes, scheme) {
+		return fmt.Sprintf("the scheme %q is not supported, valid schemes are %s", scheme, strings.Join(upstreamDnsSchemes, ", "))
+	}
+
+	if scheme == "sdns" {
+		stamp, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(rest, "="))
+		if err != nil || len(stamp) == 0 {
+			return "the DNS stamp is not valid base64url"
+		}
+		return ""
+	}
+
+	u, err := url.Parse(upstream)
+	if err != nil {
+		return fmt.Sprintf("the URL cannot be parsed: %s", err.Error())
+	}
+	if u.Host == "" {
+		return "the URL does not contain a host"
+	}
+	// only DNS-over-HTTPS upstreams can have a path
+	if scheme != "https" && scheme != "h3" && u.Path != "" && u.Path != "/" {
+		return fmt.Sprintf("upstreams with the %q scheme cannot have a path", scheme)
+	}
+
+	return validateUpstreamHostPort(u.Host)
+}
+
+// validateUpstreamHostPort - validates an IP address or hostname, optionally with a port
+func validateUpstreamHostPort(hostPort string) string {
+	host := hostPort
+	if h, port, err := net.SplitHostPort(hostPort); err == nil {
+		if reason := validateUpstreamPort(port); reason != "" {
+			return reason
+		}
+		host = h
+	}
+
+	if net.ParseIP(strings.Trim(host, "[]")) != nil || hostnameRegexp.MatchString(host) {
+		return ""
+	}
+
+	return fmt.Sprintf("%q is not a valid IP address or hostname", host)
+}
+
+// validateUpstreamPort - validates a port number
+func validateUpstreamPort(port string) string {
+	p, err := strconv.Atoi(port)
+	if err != nil || p < 1 || p > 65535 {
+		return fmt.Sprintf("%q is not a valid port", port)
+	}
+
+	return ""
+}
+
+func checkUpstreamDns() checkUpstreamDnsValidator {
+	return checkUpstreamDnsValidator{}
+}
+
+func checkBootstrapDns() checkUpstreamDnsValidator {
+	return checkUpstreamDnsValidator{bootstrap: true}
+}
@@ -0,0 +1,328 @@
+package adguard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validator confirms each user rule is valid in AdGuard DNS filtering syntax
+var _ validator.List = checkUserRulesValidator{}
+
+type checkUserRulesValidator struct {
+}
+
+// characters allowed in the pattern of a basic rule
+var userRulePatternRegexp = regexp.MustCompile(`^[\p{L}\p{N}._*|^:/\[\]-]+$`)
+
+// DNS record types accepted by the `$dnstype` and `$dnsrewrite` modifiers
+var userRuleDnsTypes = []string{
+	"A", "AAAA", "ANY", "CAA", "CNAME", "DNSKEY", "DS", "HINFO", "HTTPS", "MX", "NAPTR", "NS",
+	"NSEC", "NSEC3", "PTR", "RRSIG", "SOA", "SRV", "SSHFP", "SVCB", "TLSA", "TXT",
+}
+
+// DNS response codes accepted as the sole value of the `$dnsrewrite` modifier
+var userRuleDnsRcodeKeywords = []string{"NOERROR", "NXDOMAIN", "REFUSED", "SERVFAIL"}
+
+// DNS response codes accepted in the full `RCODE;RRTYPE;VALUE` form of the `$dnsrewrite` modifier
+var userRuleDnsRcodes = []string{
+	"NOERROR", "FORMERR", "SERVFAIL", "NXDOMAIN", "NOTIMP", "NOTIMPL", "REFUSED", "YXDOMAIN", "YXRRSET",
+	"NXRRSET", "NOTAUTH", "NOTZONE", "BADSIG", "BADVERS", "BADKEY", "BADTIME", "BADMODE", "BADNAME",
+	"BADALG", "BADTRUNC", "BADCOOKIE",
+}
+
+func (v checkUserRulesValidator) Description(_ context.Context) string {
+	return "each user rule must be valid in AdGuard DNS filtering syntax"
+}
+
+func (v checkUserRulesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v checkUserRulesValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		// nothing to validate
+		return
+	}
+
+	for i, element := range req.ConfigValue.Elements() {
+		rule, ok := element.(types.String)
+		if !ok || rule.IsNull() || rule.IsUnknown() {
+			continue
+		}
+
+		if reason := validateUserRule(rule.ValueString()); reason != "" {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i),
+				"Invalid User Rule",
+				fmt.Sprintf("User rule at index %d (%q) is invalid: %s", i, rule.ValueString(), reason),
+			)
+		}
+	}
+}
+
+// validateUserRule - returns the reason why a user rule is invalid, or an empty string if it is valid
+func validateUserRule(rule string) string {
+	line := strings.TrimSpace(rule)
+
+	// the comment lines delimiting user rules sections are reserved
+	if strings.HasPrefix(line, userRulesSectionBeginPrefix) || strings.HasPrefix(line, userRulesSectionEndPrefix) {
+		return "comments starting with \"" + userRulesSectionBeginPrefix + "\" or \"" + userRulesSectionEndPrefix + "\" are reserved for user rules sections"
+	}
+
+	// empty lines and comments are ignored by AdGuard Home
+	if line == "" || strings.HasPrefix(line, "!") || strings.HasPrefix(line, "#") {
+		return ""
+	}
+
+	// regular expression rules, optionally followed by modifiers
+	if strings.HasPrefix(line, "/") || strings.HasPrefix(line, "@@/") {
+		return validateUserRuleRegexp(strings.TrimPrefix(line, "@@"), strings.HasPrefix(line, "@@"))
+	}
+
+	pattern, modifiers, found := strings.Cut(strings.TrimPrefix(line, "@@"), "$")
+
+	// hosts-style rules
+	if strings.ContainsAny(pattern, " \t") {
+		if strings.HasPrefix(line, "@@") || found {
+			return "rules with an exception prefix or modifiers cannot contain whitespace"
+		}
+		return validateUserRuleHosts(line)
+	}
+
+	if pattern == "" {
+		return "the rule pattern is empty"
+	}
+	if !userRulePatternRegexp.MatchString(pattern) {
+		return fmt.Sprintf("the rule pattern %q contains invalid characters", pattern)
+	}
+	if strings.Contains(strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(pattern, "||"), "|"), "|"), "|") {
+		return fmt.Sprintf("the rule pattern %q can only contain `|` or `||` at its start and `|` at its end", pattern)
+	}
+
+	if found {
+		return validateUserRuleModifiers(modifiers, strings.HasPrefix(line, "@@"))
+	}
+
+	return ""
+}
+
+// validateUserRuleRegexp - validates a regular expression rule and its modifiers
+func validateUserRuleRegexp(line string, exception bool) string {
+	// the regular expression ends at the last slash followed by the modifiers or the end of the line
+	end := strings.LastIndex(line, "/$")
+	if end <= 0 {
+		if len(line) < 2 || !strings.HasSuffix(line, "/") {
+			return "regular expression rules must be enclosed in `/`"
+		}
+		end = len(line) - 1
+	}
+
+	expression := line[1:end]
+	if expression == "" {
+		return "the regular expression is empty"
+	}
+	if _, err := regexp.Compile(expression); err != nil {
+		return fmt.Sprintf("the regular expression %q does not compile: %s", expression, err.Error())
+	}
+
+	if end+1 < len(line) {
+		return validateUserRuleModifiers(line[end+2:], exception)
+	}
+
+	return ""
+}
+
+// validateUserRuleHosts - validates a rule in /etc/hosts syntax
+func validateUserRuleHosts(line string) string {
+	// inline comments are allowed after the hostnames
+	line, _, _ = strings.Cut(line, "#")
+	fields := strings.Fields(line)
+
+	if net.ParseIP(fields[0]) == nil {
+		return fmt.Sprintf("hosts-style rules must start with a valid IP address, got %q", fields[0])
+	}
+	if len(fields) < 2 {
+		return "hosts-style rules must contain at least one hostname after the IP address"
+	}
+	for _, hostname := range fields[1:] {
+		if !hostnameRegexp.MatchString(hostname) {
+			return fmt.Sprintf("%q is not a valid hostname", hostname)
+		}
+	}
+
+	return ""
+}
+
+// validateUserRuleModifiers - validates the comma-separated modifiers following the `$` of a rule
+func validateUserRuleModifiers(modifiers string, exception bool) string {
+	if modifiers == "" {
+		return "the rule has a `$` but no modifiers"
+	}
+
+	seen := make(map[string]bool)
+	for _, modifier := range splitUserRuleModifier(modifiers, ',') {
+		name, value, hasValue := strings.Cut(modifier, "=")
+		if seen[name] {
+			return fmt.Sprintf("the modifier `$%s` is specified more than once", name)
+		}
+		seen[name] = true
+
+		switch name {
+		case "important", "badfilter":
+			if hasValue {
+				return fmt.Sprintf("the modifier `$%s` does not accept a value", name)
+			}
+			continue
+		case "dnsrewrite":
+			// exception rules can disable all DNS rewrites of a domain with a value-less modifier
+			if exception && !hasValue {
+				continue
+			}
+			if !hasValue || value == "" {
+				return fmt.Sprintf("the modifier `$%s` requires a value", name)
+			}
+		case "client", "ctag", "denyallow", "dnstype":
+			if !hasValue || value == "" {
+				return fmt.Sprintf("the modifier `$%s` requires a value", name)
+			}
+		case "":
+			return "the rule contains an empty modifier"
+		default:
+			return fmt.Sprintf("the modifier `$%s` is not supported in DNS filtering rules", name)
+		}
+
+		if reason := validateUserRuleModifierValue(name, value); reason != "" {
+			return reason
+		}
+	}
+
+	return ""
+}
+
+// validateUserRuleModifierValue - validates the value of a modifier accepting one
+func validateUserRuleModifierValue(name string, value string) string {
+	if name == "dnsrewrite" {
+		return validateUserRuleDnsRewrite(value)
+	}
+
+	for _, v := range splitUserRuleModifier(value, '|') {
+		v = unquoteUserRuleValue(v)
+		if v == "" || v == "~" {
+			return fmt.Sprintf("the modifier `$%s` contains an empty value", name)
+		}
+
+		switch name {
+		case "dnstype":
+			if !contains(userRuleDnsTypes, strings.ToUpper(strings.TrimPrefix(v, "~"))) {
+				return fmt.Sprintf("the modifier `$dnstype` contains the unknown DNS record type %q", v)
+			}
+		case "denyallow":
+			if strings.HasPrefix(v, "~") || strings.Contains(v, "*") || !hostnameRegexp.MatchString(v) {
+				return fmt.Sprintf("the modifier `$denyallow` contains the invalid domain %q", v)
+			}
+		}
+	}
+
+	return ""
+}
+
+// validateUserRuleDnsRewrite - validates the value of the `$dnsrewrite` modifier, either
+// a response code, an IP address, a hostname or the full `RCODE;RRTYPE;VALUE` form
+func validateUserRuleDnsRewrite(value string) string {
+	parts := strings.Split(value, ";")
+	switch len(parts) {
+	case 1:
+		if contains(userRuleDnsRcodeKeywords, parts[0]) || net.ParseIP(parts[0]) != nil || hostnameRegexp.MatchString(parts[0]) {
+			return ""
+		}
+		return fmt.Sprintf("the modifier `$dnsrewrite` value %q is not a response code, IP address or hostname", value)
+	case 3:
+		rcode := strings.ToUpper(parts[0])
+		rrtype := strings.ToUpper(parts[1])
+		if !contains(userRuleDnsRcodes, rcode) {
+			return fmt.Sprintf("the modifier `$dnsrewrite` contains the unknown response code %q", parts[0])
+		}
+		if rrtype != "" && !contains(userRuleDnsTypes, rrtype) {
+			return fmt.Sprintf("the modifier `$dnsrewrite` contains the unknown DNS record type %q", parts[1])
+		}
+		// the record type and value are ignored for any other response code
+		if rcode != "NOERROR" {
+			return ""
+		}
+		return validateUserRuleDnsRewriteValue(rrtype, parts[2])
+	default:
+		return fmt.Sprintf("the modifier `$dnsrewrite` value %q must be in the form `RCODE;RRTYPE;VALUE`", value)
+	}
+}
+
+// validateUserRuleDnsRewriteValue - validates the value of the full `$dnsrewrite` form against its record type,
+// for the record types with a well-defined value
+func validateUserRuleDnsRewriteValue(rrtype string, value string) string {
+	switch rrtype {
+	case "A":
+		if addr, err := netip.ParseAddr(value); err != nil || !addr.Is4() {
+			return fmt.Sprintf("the modifier `$dnsrewrite` value %q of an A record must be an IPv4 address", value)
+		}
+	case "AAAA":
+		if addr, err := netip.ParseAddr(value); err != nil || !addr.Is6() {
+			return fmt.Sprintf("the modifier `$dnsrewrite` value %q of an AAAA record must be an IPv6 address", value)
+		}
+	case "CNAME", "PTR":
+		if !hostnameRegexp.MatchString(value) {
+			return fmt.Sprintf("the modifier `$dnsrewrite` value %q of a %s record must be a hostname", value, rrtype)
+		}
+	case "MX":
+		// MX values consist of the preference and the mail exchange hostname, as in `10 mail.example.org`
+		preference, exchange, ok := strings.Cut(value, " ")
+		if _, err := strconv.ParseUint(preference, 10, 16); !ok || err != nil || !hostnameRegexp.MatchString(exchange) {
+			return fmt.Sprintf("the modifier `$dnsrewrite` value %q of an MX record must be a preference followed by a hostname", value)
+		}
+	}
+
+	return ""
+}
+
+func checkUserRules() validator.List {
+	return checkUserRulesValidator{}
+}
+
+// validator confirms the pattern of a structured user rule is valid in AdGuard DNS filtering syntax
+var _ validator.String = checkUserRulePatternValidator{}
+
+type checkUserRulePatternValidator struct {
+}
+
+func (v checkUserRulePatternValidator) Description(_ context.Context) string {
+	return "the rule pattern must be valid in AdGuard DNS filtering syntax"
+}
+
+func (v checkUserRulePatternValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v checkUserRulePatternValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		// nothing to validate
+		return
+	}
+
+	if reason := validateUserRule(req.ConfigValue.ValueString()); reason != "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid User Rule",
+			fmt.Sprintf("User rule pattern %q is invalid: %s", req.ConfigValue.ValueString(), reason),
+		)
+	}
+}
+
+func checkUserRulePattern() validator.String {
+	return checkUserRulePatternValidator{}
+}
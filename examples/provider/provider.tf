@@ -6,4 +6,11 @@ provider "adguard" {
   scheme   = "http" # defaults to https
   timeout  = 5      # in seconds, defaults to 10
   insecure = false  # when `true` will skip TLS validation
+
+  retry = {
+    max_attempts           = 5  # defaults to 3
+    min_backoff            = 1  # in seconds, defaults to 1
+    max_backoff            = 30 # in seconds, defaults to 30
+    retryable_status_codes = [429, 502, 503, 504]
+  }
 }
@@ -0,0 +1,2 @@
+# DNS access rule can be imported by specifying the type and value separated by double pipes (||)
+terraform import adguard_access_rule.test "blocked_host||example.net"
@@ -0,0 +1,6 @@
+# manage a single DNS access list entry
+# when adguard_config is also used, set `manage_access_list = false` in its `dns` block
+resource "adguard_access_rule" "test" {
+  type  = "blocked_host"
+  value = "example.net"
+}
@@ -0,0 +1,3 @@
+# Blocked services can be imported by specifying the ID as `1`
+# NOTE: there can only be 1 (one) `adguard_blocked_services` resource, hence the hardcoded ID
+terraform import adguard_blocked_services.test "1"
@@ -0,0 +1,11 @@
+# manage the globally blocked services and their pause schedule
+resource "adguard_blocked_services" "test" {
+  blocked_services = ["youtube", "pinterest"]
+  blocked_services_pause_schedule = {
+    time_zone = "America/New_York"
+    sat = {
+      start = "09:00"
+      end   = "18:00"
+    }
+  }
+}
@@ -0,0 +1,3 @@
+# The clients policy can be imported by specifying the ID as `1`
+# NOTE: there can only be 1 (one) `adguard_clients_policy` resource, hence the hardcoded ID
+terraform import adguard_clients_policy.test "1"
@@ -0,0 +1,5 @@
+# report persistent clients whose name does not start with `tf-`
+# use `managed_tag` instead to match the clients by tag, and `mode = "delete"` to delete unmanaged clients
+resource "adguard_clients_policy" "test" {
+  managed_name_prefix = "tf-"
+}
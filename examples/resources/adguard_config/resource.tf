@@ -73,3 +73,16 @@ resource "adguard_config" "test" {
     private_key       = "/opt/adguardhome/ssl/server.key"
   }
 }
+
+# alternatively, manage only the sections of the server configuration set in the resource,
+# leaving all other sections alone
+# NOTE: partial ownership is opt-in for backward compatibility, so without it all sections are managed
+resource "adguard_config" "partial" {
+  partial_ownership = true
+
+  safebrowsing = true
+
+  querylog = {
+    interval = 24
+  }
+}
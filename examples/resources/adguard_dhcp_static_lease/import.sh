@@ -0,0 +1,2 @@
+# DHCP static lease can be imported by specifying its MAC address
+terraform import adguard_dhcp_static_lease.test "00:11:22:33:44:55"
@@ -0,0 +1,6 @@
+# manage a DHCP static lease
+resource "adguard_dhcp_static_lease" "test" {
+  mac      = "00:11:22:33:44:55"
+  ip       = "192.168.250.20"
+  hostname = "test-lease"
+}
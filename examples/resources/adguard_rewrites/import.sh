@@ -0,0 +1,5 @@
+# DNS rewrite rules can be imported by specifying the ID as `1`, adopting all existing DNS rewrite rules
+# NOTE: there can only be 1 (one) `adguard_rewrites` resource managing the DNS rewrite rules, hence the hardcoded ID
+# NOTE: all existing DNS rewrite rules are adopted regardless of `exclusive`, so the next plan deletes the ones
+# missing from the configuration. Add the DNS rewrite rules to keep to the configuration before applying
+terraform import adguard_rewrites.test "1"
@@ -0,0 +1,15 @@
+# manage DNS rewrite rules as a collection
+resource "adguard_rewrites" "test" {
+  rewrites = [
+    {
+      domain = "example.com"
+      answer = "4.3.2.1"
+    },
+    {
+      domain  = "*.example.org"
+      answer  = "example.com"
+      enabled = false
+    },
+  ]
+  exclusive = true
+}
@@ -0,0 +1,3 @@
+# TLS config can be imported by specifying the ID as `1`
+# NOTE: there can only be 1 (one) `adguard_tls` resource, hence the hardcoded ID
+terraform import adguard_tls.test "1"
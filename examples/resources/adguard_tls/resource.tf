@@ -0,0 +1,7 @@
+# manage the TLS config using certificate files on the AdGuard Home server
+resource "adguard_tls" "test" {
+  enabled          = true
+  server_name      = "adguard.example.com"
+  certificate_path = "/opt/adguardhome/ssl/server.crt"
+  private_key_path = "/opt/adguardhome/ssl/server.key"
+}
@@ -1,3 +1,6 @@
 # User rules can be imported by specifying the ID as `1`
-# NOTE: there can only be 1 (one) `adguard_user_rules` resource, hence the hardcoded ID
+# NOTE: there can only be 1 (one) `adguard_user_rules` resource managing the entire user rules, hence the hardcoded ID
 terraform import adguard_user_rules.test "1"
+
+# A section of user rules can be imported by specifying its name prefixed with `section:` as the ID
+terraform import adguard_user_rules.section "section:ads"
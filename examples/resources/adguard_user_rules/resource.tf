@@ -1,5 +1,5 @@
 # manage user rules
-# NOTE: there can only be 1 (one) `adguard_user_rules` resource
+# NOTE: there can only be 1 (one) `adguard_user_rules` resource managing the entire user rules
 # specifying multiple resources will result in errors
 resource "adguard_user_rules" "test" {
   rules = [
@@ -12,3 +12,31 @@ resource "adguard_user_rules" "test" {
     "||blocked.org^"
   ]
 }
+
+# alternatively, manage only a section of the user rules,
+# leaving rules outside of the section alone
+resource "adguard_user_rules" "section" {
+  section = "ads"
+  rules = [
+    "||ads.example.org^",
+    "||tracker.example.org^"
+  ]
+}
+
+# rules can also be specified as structured objects,
+# which are rendered in AdGuard filtering syntax after the raw rules
+resource "adguard_user_rules" "structured" {
+  section = "kids"
+  rule = [
+    {
+      pattern = "||games.example.org^"
+      client  = ["kids-tablet"]
+      comment = "no games on the kids tablet"
+    },
+    {
+      pattern   = "||school.example.org^"
+      action    = "allow"
+      important = true
+    }
+  ]
+}